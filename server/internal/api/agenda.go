@@ -0,0 +1,65 @@
+package api
+
+import (
+	"server/internal/api/spec"
+	"server/internal/pgstore"
+	"sort"
+	"time"
+)
+
+// agenda groups activities, which must be sorted by occurs_at, into one
+// bucket per calendar day in loc from the day the trip starts to the day it
+// ends, empty days included. Activities outside the trip dates get a bucket of
+// their own so they are never hidden. Activities with a zone of their own are
+// still placed on the trip day but show their time in that zone.
+func agenda(startsAt, endsAt time.Time, activities []pgstore.Activity, loc *time.Location, now time.Time) []spec.GetTripActivitiesResponseOuterArray {
+	var days []spec.GetTripActivitiesResponseOuterArray
+	index := make(map[string]int)
+	addDay := func(date time.Time) int {
+		days = append(days, spec.GetTripActivitiesResponseOuterArray{
+			Date:       date,
+			Activities: []spec.GetTripActivitiesResponseInnerArray{},
+		})
+		index[date.Format(time.DateOnly)] = len(days) - 1
+		return len(days) - 1
+	}
+
+	first := startOfDay(startsAt, loc)
+	last := startOfDay(endsAt, loc)
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		addDay(day)
+	}
+
+	for _, act := range activities {
+		day := startOfDay(act.OccursAt.Time, loc)
+		i, ok := index[day.Format(time.DateOnly)]
+		if !ok {
+			i = addDay(day)
+		}
+
+		actLoc := loc
+		if act.Timezone.Valid {
+			actLoc = pgstore.Location(act.Timezone.String)
+		}
+
+		days[i].Activities = append(days[i].Activities, spec.GetTripActivitiesResponseInnerArray{
+			ID:       act.ID.String(),
+			OccursAt: act.OccursAt.Time.In(actLoc),
+			Timezone: textPtr(act.Timezone),
+			Title:    act.Title,
+			Past:     act.OccursAt.Time.Before(now),
+			Version:  int(act.Version),
+		})
+	}
+
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Date.Before(days[j].Date)
+	})
+
+	return days
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
@@ -4,9 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"server/internal/api/spec"
+	"server/internal/auth"
+	"server/internal/email"
+	"server/internal/ical"
 	"server/internal/pgstore"
+	"server/internal/tripdiff"
+	"time"
 
 	"github.com/discord-gophers/goapi-gen/types"
 	"github.com/go-playground/validator/v10"
@@ -18,67 +25,347 @@ import (
 )
 
 type store interface {
-	ConfirmParticipant(ctx context.Context, id uuid.UUID) error
+	ClaimDueEmails(ctx context.Context, limit int32) ([]pgstore.EmailOutbox, error)
+	ConsumeLoginToken(ctx context.Context, id uuid.UUID) (int64, error)
 	CreateActivity(ctx context.Context, arg pgstore.CreateActivityParams) (uuid.UUID, error)
 	CreateTripLink(ctx context.Context, arg pgstore.CreateTripLinkParams) (uuid.UUID, error)
-	GetParticipant(ctx context.Context, id uuid.UUID) (pgstore.Participant, error)
-	GetParticipants(ctx context.Context, tripID uuid.UUID) ([]pgstore.Participant, error)
-	GetTrip(ctx context.Context, id uuid.UUID) (pgstore.Trip, error)
+	DeleteActivity(ctx context.Context, arg pgstore.DeleteActivityParams) (int64, error)
+	DeleteLink(ctx context.Context, arg pgstore.DeleteLinkParams) (int64, error)
+	DeleteParticipant(ctx context.Context, arg pgstore.DeleteParticipantParams) (int64, error)
+	DeleteTrip(ctx context.Context, id uuid.UUID) (int64, error)
+	GetCalendarParticipant(ctx context.Context, arg pgstore.GetCalendarParticipantParams) (uuid.UUID, error)
+	GetParticipant(ctx context.Context, id uuid.UUID) (pgstore.GetParticipantRow, error)
+	GetParticipantCalendarTokens(ctx context.Context, ids []uuid.UUID) ([]pgstore.GetParticipantCalendarTokensRow, error)
+	GetParticipantEmails(ctx context.Context, participantID pgtype.UUID) ([]pgstore.GetParticipantEmailsRow, error)
+	GetParticipants(ctx context.Context, tripID uuid.UUID) ([]pgstore.GetParticipantsRow, error)
+	GetTrip(ctx context.Context, id uuid.UUID) (pgstore.GetTripRow, error)
 	GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]pgstore.Activity, error)
+	GetTripActivity(ctx context.Context, arg pgstore.GetTripActivityParams) (pgstore.Activity, error)
+	GetTripLink(ctx context.Context, arg pgstore.GetTripLinkParams) (pgstore.Link, error)
 	GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]pgstore.Link, error)
+	GetTripParticipantByUser(ctx context.Context, arg pgstore.GetTripParticipantByUserParams) (pgstore.Participant, error)
+	GetUser(ctx context.Context, id uuid.UUID) (pgstore.User, error)
+	InsertConfirmationToken(ctx context.Context, arg pgstore.InsertConfirmationTokenParams) (uuid.UUID, error)
+	InsertInviteConfirmationTokens(ctx context.Context, arg pgstore.InsertInviteConfirmationTokensParams) ([]pgstore.InsertInviteConfirmationTokensRow, error)
 	InsertTrip(ctx context.Context, arg pgstore.InsertTripParams) (uuid.UUID, error)
-	InviteParticipantToTrip(ctx context.Context, arg pgstore.InviteParticipantToTripParams) (uuid.UUID, error)
-	UpdateTrip(ctx context.Context, arg pgstore.UpdateTripParams) error
+	ListUserTrips(ctx context.Context, arg pgstore.ListUserTripsParams) ([]pgstore.Trip, error)
+	MarkEmailFailed(ctx context.Context, arg pgstore.MarkEmailFailedParams) error
+	MarkEmailSent(ctx context.Context, id uuid.UUID) error
+	UpdateActivity(ctx context.Context, arg pgstore.UpdateActivityParams) (int32, error)
+	UpdateLink(ctx context.Context, arg pgstore.UpdateLinkParams) (int32, error)
+	UpdateParticipantNotifyChanges(ctx context.Context, arg pgstore.UpdateParticipantNotifyChangesParams) (int64, error)
+	UpdateParticipantRole(ctx context.Context, arg pgstore.UpdateParticipantRoleParams) error
+	UpdateTripStatus(ctx context.Context, arg pgstore.UpdateTripStatusParams) (int64, error)
+	UpdateUser(ctx context.Context, arg pgstore.UpdateUserParams) error
+	UpsertUser(ctx context.Context, arg pgstore.UpsertUserParams) (uuid.UUID, error)
+	AnswerInvite(ctx context.Context, pool *pgxpool.Pool, params pgstore.UpdateParticipantRsvpParams, tokenID uuid.UUID) (int64, error)
+	CancelTrip(ctx context.Context, pool *pgxpool.Pool, params pgstore.UpdateTripStatusParams) (int64, error)
+	ChangeUserEmail(ctx context.Context, pool *pgxpool.Pool, params pgstore.ConsumeEmailChangeTokenParams) (string, error)
+	ConfirmTrip(ctx context.Context, pool *pgxpool.Pool, params pgstore.UpdateTripStatusParams, tokenID uuid.UUID) (int64, error)
+	CreateLoginToken(ctx context.Context, pool *pgxpool.Pool, params pgstore.InsertLoginTokenParams, email func(tokenID uuid.UUID) (pgstore.EnqueueEmailParams, error)) error
 	CreateTrip(ctx context.Context, pool *pgxpool.Pool, params spec.CreateTripRequest) (uuid.UUID, error)
+	GetTripAggregate(ctx context.Context, pool *pgxpool.Pool, tripID uuid.UUID, include pgstore.TripIncludes) (pgstore.TripAggregate, error)
+	InviteParticipant(ctx context.Context, pool *pgxpool.Pool, params pgstore.InviteParticipantToTripParams, email string) (uuid.UUID, error)
+	ReorderTripLinks(ctx context.Context, pool *pgxpool.Pool, tripID uuid.UUID, linkIDs []uuid.UUID) error
+	RequestEmailChange(ctx context.Context, pool *pgxpool.Pool, params pgstore.InsertEmailChangeTokenParams, email func(tokenID uuid.UUID) (pgstore.EnqueueEmailParams, error)) error
+	RescheduleTrip(ctx context.Context, pool *pgxpool.Pool, params pgstore.UpdateTripParams, activityIDs []uuid.UUID, emails pgstore.TripUpdateEmails) (pgstore.Trip, error)
+	ResendInvite(ctx context.Context, pool *pgxpool.Pool, params pgstore.MarkParticipantInvitedParams, email string) (int64, error)
 }
 
 type mailer interface {
-	SendConfirmTripEmailToTripOwner(uuid.UUID) error
-	SendInviteToTripEmail(uuid.UUID, string) error
+	SendChangeEmailLink(context.Context, string, string) error
+	SendConfirmTripEmailToTripOwner(context.Context, uuid.UUID, string) error
+	SendInvites(context.Context, uuid.UUID, []email.Invite) []error
+	SendLoginLinkEmail(context.Context, string, string) error
+	SendTripCancelledEmail(context.Context, uuid.UUID, string) error
+	SendTripUpdatedEmail(context.Context, uuid.UUID, string, []tripdiff.Change, string) error
+	Preview(context.Context, string, uuid.UUID, string) (email.Rendered, error)
+	SendPreview(context.Context, string, uuid.UUID, string, string) error
 }
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// resendInviteInterval is how long organizers wait between invite e-mails
+	// to the same participant.
+	resendInviteInterval = time.Hour
+)
+
 type API struct {
 	store     store
 	logger    *zap.Logger
 	validator *validator.Validate
 	pool      *pgxpool.Pool
 	mailer    mailer
+	signer    auth.Signer
+	baseURL   string
 }
 
-func NewAPI(pool *pgxpool.Pool, logger *zap.Logger, mailer mailer) API {
+func NewAPI(pool *pgxpool.Pool, logger *zap.Logger, mailer mailer, signer auth.Signer, baseURL string) API {
 	validator := validator.New(validator.WithRequiredStructEnabled())
-	return API{pgstore.New(pool), logger, validator, pool, mailer}
+	registerValidations(validator)
+	return API{pgstore.New(pool), logger, validator, pool, mailer, signer, baseURL}
+}
+
+// Send a one-time login link to an e-mail.
+// (POST /auth/login)
+func (api *API) PostAuthLogin(w http.ResponseWriter, r *http.Request) *spec.Response {
+	var body spec.PostAuthLoginJSONBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	email := pgstore.NormalizeEmail(string(body.Email))
+	expiresAt := time.Now().Add(auth.LoginTokenTTL)
+	err = api.store.CreateLoginToken(r.Context(), api.pool,
+		pgstore.InsertLoginTokenParams{
+			Email:     email,
+			ExpiresAt: pgtype.Timestamptz{Valid: true, Time: expiresAt},
+		},
+		func(tokenID uuid.UUID) (pgstore.EnqueueEmailParams, error) {
+			return emailParams(pgstore.EmailLoginLink, uuid.Nil, uuid.Nil, email,
+				loginLinkPayload{TokenID: tokenID, ExpiresAt: expiresAt},
+			)
+		},
+	)
+	if err != nil {
+		api.logger.Error("Failed to create login link", zap.Error(err))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to create login link, try again")
+	}
+
+	return spec.PostAuthLoginJSON204Response(nil)
+}
+
+// Show the page that exchanges a login link token for a session token.
+// (GET /auth/login/verify)
+func (api *API) GetAuthLoginVerify(w http.ResponseWriter, r *http.Request, params spec.GetAuthLoginVerifyParams) *spec.Response {
+	_, perr := api.verifyLoginToken(params.Token)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	return api.writeActionPage(w, r, actionPage{
+		Title:   "Log in",
+		Message: "Log in to Travel Planner?",
+		Button:  "Log in",
+		Done:    "You are logged in.",
+	})
+}
+
+// Exchange a login link token for a session token.
+// (POST /auth/login/verify)
+func (api *API) PostAuthLoginVerify(w http.ResponseWriter, r *http.Request, params spec.PostAuthLoginVerifyParams) *spec.Response {
+	claims, perr := api.verifyLoginToken(params.Token)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	consumed, err := api.store.ConsumeLoginToken(r.Context(), claims.ID)
+	if err != nil {
+		api.logger.Error("Failed to consume login token", zap.Error(err), zap.String("token_id", claims.ID.String()))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong verifying login link, try again")
+	}
+
+	if consumed == 0 {
+		return api.problem(w, r, http.StatusConflict, codeTokenUsed, "Login link already used")
+	}
+
+	userID, err := api.store.UpsertUser(r.Context(), pgstore.UpsertUserParams{Email: claims.Subject})
+	if err != nil {
+		api.logger.Error("Failed to upsert user", zap.Error(err))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong verifying login link, try again")
+	}
+
+	expiresAt := time.Now().Add(auth.SessionTokenTTL)
+	token, err := api.signer.Sign(auth.Claims{
+		ID:        uuid.New(),
+		Subject:   userID.String(),
+		Purpose:   auth.PurposeSession,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		api.logger.Error("Failed to sign session token", zap.Error(err))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong verifying login link, try again")
+	}
+
+	return spec.PostAuthLoginVerifyJSON200Response(spec.LoginResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+// Show the page that confirms a participant on a trip.
+// (GET /participants/{participantId}/confirm)
+func (api *API) GetParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string, params spec.GetParticipantsParticipantIDConfirmParams) *spec.Response {
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	_, perr := api.verifyLinkToken(params.Token, auth.PurposeConfirmParticipant, id, "Invite")
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	return api.writeActionPage(w, r, actionPage{
+		Title:   "Join the trip",
+		Message: "Confirm you are going on the trip?",
+		Button:  "I'm in",
+		Done:    "You are confirmed on the trip.",
+	})
 }
 
 // Confirms a participant on a trip.
-// (PATCH /participants/{participantId}/confirm)
-func (api *API) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string) *spec.Response {
+// (POST /participants/{participantId}/confirm)
+func (api *API) PostParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string, params spec.PostParticipantsParticipantIDConfirmParams) *spec.Response {
 	id, err := uuid.Parse(participantID)
 	if err != nil {
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "invalid uuid"})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
 	}
 
-	participant, err := api.store.GetParticipant(r.Context(), id)
+	if err := api.answerInvite(r.Context(), id, params.Token, rsvpConfirmed); err != nil {
+		return api.problem(w, r, err.status, err.code, err.detail)
+	}
+
+	return spec.PostParticipantsParticipantIDConfirmJSON204Response(nil)
+}
+
+// Show the page that declines a participant invite to a trip.
+// (GET /participants/{participantId}/decline)
+func (api *API) GetParticipantsParticipantIDDecline(w http.ResponseWriter, r *http.Request, participantID string, params spec.GetParticipantsParticipantIDDeclineParams) *spec.Response {
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	_, perr := api.verifyLinkToken(params.Token, auth.PurposeConfirmParticipant, id, "Invite")
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	return api.writeActionPage(w, r, actionPage{
+		Title:   "Decline the invite",
+		Message: "Let the organizer know you can't make it to the trip?",
+		Button:  "Decline invite",
+		Done:    "You declined the invite.",
+	})
+}
+
+// Declines a participant invite to a trip.
+// (POST /participants/{participantId}/decline)
+func (api *API) PostParticipantsParticipantIDDecline(w http.ResponseWriter, r *http.Request, participantID string, params spec.PostParticipantsParticipantIDDeclineParams) *spec.Response {
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	if err := api.answerInvite(r.Context(), id, params.Token, rsvpDeclined); err != nil {
+		return api.problem(w, r, err.status, err.code, err.detail)
+	}
+
+	return spec.PostParticipantsParticipantIDDeclineJSON204Response(nil)
+}
+
+// Show the page that stops e-mails about changes to a trip for a participant.
+// (GET /participants/{participantId}/unsubscribe)
+func (api *API) GetParticipantsParticipantIDUnsubscribe(w http.ResponseWriter, r *http.Request, participantID string, params spec.GetParticipantsParticipantIDUnsubscribeParams) *spec.Response {
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	_, perr := api.verifyLinkToken(params.Token, auth.PurposeUnsubscribe, id, "Unsubscribe")
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	return api.writeActionPage(w, r, actionPage{
+		Title:   "Unsubscribe",
+		Message: "Stop e-mails about changes to this trip?",
+		Button:  "Unsubscribe",
+		Done:    "You will no longer get e-mails about changes to this trip.",
+	})
+}
+
+// Stops e-mails about changes to a trip for a participant.
+// (POST /participants/{participantId}/unsubscribe)
+func (api *API) PostParticipantsParticipantIDUnsubscribe(w http.ResponseWriter, r *http.Request, participantID string, params spec.PostParticipantsParticipantIDUnsubscribeParams) *spec.Response {
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	_, perr := api.verifyLinkToken(params.Token, auth.PurposeUnsubscribe, id, "Unsubscribe")
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	updated, err := api.store.UpdateParticipantNotifyChanges(r.Context(),
+		pgstore.UpdateParticipantNotifyChangesParams{
+			NotifyChanges: false,
+			ID:            id,
+		},
+	)
+	if err != nil {
+		api.logger.Error("Failed to update participant notifications", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong unsubscribing, try again")
+	}
+
+	if updated == 0 {
+		return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+	}
+
+	return spec.PostParticipantsParticipantIDUnsubscribeJSON204Response(nil)
+}
+
+// answerInvite records the answer of a participant to an invite using the
+// token from the invite e-mail. It returns the problem to reply with when the
+// answer could not be recorded.
+func (api *API) answerInvite(ctx context.Context, id uuid.UUID, token string, answer string) *apiError {
+	claims, perr := api.verifyLinkToken(token, auth.PurposeConfirmParticipant, id, "Invite")
+	if perr != nil {
+		return perr
+	}
+
+	participant, err := api.store.GetParticipant(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Participant not found"})
+			return &apiError{http.StatusNotFound, codeParticipantNotFound, "Participant not found"}
 		}
 
-		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Something went wrong finding participant, try again"})
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", id.String()))
+		return &apiError{http.StatusInternalServerError, codeInternal, "Something went wrong finding participant, try again"}
 	}
 
-	if participant.IsConfirmed {
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Participant already confirmed"})
+	if participant.RsvpStatus != rsvpPending {
+		return &apiError{http.StatusConflict, codeAlreadyAnswered, "Participant already " + participant.RsvpStatus}
 	}
 
-	err = api.store.ConfirmParticipant(r.Context(), id)
+	updated, err := api.store.AnswerInvite(ctx, api.pool,
+		pgstore.UpdateParticipantRsvpParams{
+			RsvpStatus: answer,
+			ID:         id,
+		},
+		claims.ID,
+	)
 	if err != nil {
-		api.logger.Error("Failed to confirm participant", zap.Error(err), zap.String("participant_ id", participantID))
-		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(spec.Error{Message: "Something went wrong confirming participant, try again"})
+		if errors.Is(err, pgstore.ErrTokenUsed) {
+			return &apiError{http.StatusConflict, codeTokenUsed, "Invite link already used"}
+		}
+
+		api.logger.Error("Failed to answer invite", zap.Error(err), zap.String("participant_id", id.String()))
+		return &apiError{http.StatusInternalServerError, codeInternal, "Something went wrong answering invite, try again"}
+	}
+
+	if updated == 0 {
+		return &apiError{http.StatusConflict, codeAlreadyAnswered, "Participant already answered the invite"}
 	}
 
-	return spec.PatchParticipantsParticipantIDConfirmJSON204Response(nil)
+	return nil
 }
 
 // Create a new trip
@@ -87,366 +374,1895 @@ func (api *API) PostTrips(w http.ResponseWriter, r *http.Request) *spec.Response
 	var body spec.CreateTripRequest
 	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		return spec.PostTripsJSON400Response(spec.Error{Message: "Invalid JSON"})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
 	}
 
 	err = api.validator.Struct(body)
 	if err != nil {
-		return spec.PostTripsJSON400Response(spec.Error{Message: "Invalid input: " + err.Error()})
+		return api.invalidInput(w, r, err)
 	}
 
 	tripID, err := api.store.CreateTrip(r.Context(), api.pool, body)
 	if err != nil {
-		return spec.PostTripsJSON400Response(spec.Error{Message: "Failed to create trip, try again"})
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to create trip, try again")
 	}
 
-	go func() {
-		err := api.mailer.SendConfirmTripEmailToTripOwner(tripID)
-		if err != nil {
-			api.logger.Error(
-				"failed to send email on PostTrips",
-				zap.Error(err),
-				zap.String("trip_id", tripID.String()),
-			)
-		}
-	}()
-
 	return spec.PostTripsJSON201Response(spec.CreateTripResponse{TripID: tripID.String()})
 }
 
 // Get a trip details.
 // (GET /trips/{tripId})
-func (api *API) GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+func (api *API) GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string, params spec.GetTripsTripIDParams) *spec.Response {
 	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "invalid uuid"})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
 	}
 
-	trip, err := api.store.GetTrip(r.Context(), id)
+	var include pgstore.TripIncludes
+	for _, name := range params.Include {
+		switch name {
+		case "participants":
+			include.Participants = true
+		case "activities":
+			include.Activities = true
+		case "links":
+			include.Links = true
+		default:
+			return api.problem(w, r, http.StatusBadRequest, codeInvalidParameter, "Invalid input: include must list participants, activities or links")
+		}
+	}
+
+	agg, err := api.store.GetTripAggregate(r.Context(), api.pool, id, include)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "Trip not found"})
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
 		}
 
-		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", trip.ID.String()))
-		return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "Something went wrong finding trip, try again"})
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
 	}
 
-	return spec.GetTripsTripIDJSON200Response(spec.GetTripDetailsResponse{
+	trip := agg.Trip
+	loc := pgstore.Location(trip.Timezone)
+	response := spec.GetTripDetailsResponse{
 		Trip: spec.GetTripDetailsResponseTripObj{
 			ID:          trip.ID.String(),
 			Destination: trip.Destination,
-			StartsAt:    trip.StartsAt.Time,
-			EndsAt:      trip.EndsAt.Time,
-			IsConfirmed: trip.IsConfirmed,
+			StartsAt:    trip.StartsAt.Time.In(loc),
+			EndsAt:      trip.EndsAt.Time.In(loc),
+			Timezone:    trip.Timezone,
+			IsConfirmed: tripIsConfirmed(trip.Status),
+			Status:      tripStatus(trip.Status),
+			Version:     int(trip.Version),
 		},
-	})
+		Participants: api.participantsResponse(agg.Participants),
+		Links:        linksResponse(agg.Links),
+	}
+	if include.Activities {
+		response.Activities = agenda(trip.StartsAt.Time, trip.EndsAt.Time, agg.Activities, loc, time.Now())
+	}
+
+	// Only the bare trip can be changed with If-Match, so only it gets the
+	// version as ETag.
+	etag := versionETag(trip.Version)
+	if include != (pgstore.TripIncludes{}) {
+		etag = bodyETag(response)
+	}
+	if notModified(w, r, etag) {
+		return nil
+	}
+
+	return spec.GetTripsTripIDJSON200Response(response)
 }
 
 // Update a trip.
 // (PUT /trips/{tripId})
-func (api *API) PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+func (api *API) PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string, params spec.PutTripsTripIDParams) *spec.Response {
 	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "invalid uuid"})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
 	}
 
-	var body spec.PutTripsTripIDJSONBody
+	deleteOutOfRange, ok := outOfRangeParam((*string)(params.OutOfRange))
+	if !ok {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidParameter, "Invalid input: out_of_range must be one of reject, delete")
+	}
+
+	var body spec.UpdateTripRequest
 	err = json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Invalid JSON"})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
 	}
 
 	err = api.validator.Struct(body)
 	if err != nil {
-		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Invalid input: " + err.Error()})
+		return api.invalidInput(w, r, err)
 	}
 
-	err = api.store.UpdateTrip(r.Context(),
-		pgstore.UpdateTripParams{
-			Destination: body.Destination,
-			EndsAt:      pgtype.Timestamp{Valid: true, Time: body.EndsAt},
-			StartsAt:    pgtype.Timestamp{Valid: true, Time: body.StartsAt},
-			IsConfirmed: false,
-			ID:          id,
-		},
-	)
-	if err != nil {
-		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Failed to update trip, try again"})
+	patch := spec.PatchTripRequest{
+		Destination: &body.Destination,
+		StartsAt:    &body.StartsAt,
+		EndsAt:      &body.EndsAt,
+		Timezone:    body.Timezone,
+	}
+	if !api.updateTrip(w, r, id, patch, deleteOutOfRange) {
+		return nil
 	}
 
 	return spec.PutTripsTripIDJSON204Response(nil)
 }
 
-// Get a trip activities.
-// (GET /trips/{tripId}/activities)
-func (api *API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+// Partially update a trip.
+// (PATCH /trips/{tripId})
+func (api *API) PatchTripsTripID(w http.ResponseWriter, r *http.Request, tripID string, params spec.PatchTripsTripIDParams) *spec.Response {
 	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "invalid uuid"})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
 	}
 
-	activities, err := api.store.GetTripActivities(r.Context(), id)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Activities not found"})
-		}
-
-		api.logger.Error("Failed to get activities from trip", zap.Error(err), zap.String("trip_id", id.String()))
-		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Something went wrong finding activities from trip, try again"})
-	}
-
-	var response spec.GetTripActivitiesResponse
-	for _, act := range activities {
-		response.Activities = append(response.Activities, spec.GetTripActivitiesResponseOuterArray{
-			Date: act.OccursAt.Time,
-			Activities: []spec.GetTripActivitiesResponseInnerArray{
-				{
-					ID:       act.ID.String(),
-					OccursAt: act.OccursAt.Time,
-					Title:    act.Title,
-				},
-			},
-		})
+	deleteOutOfRange, ok := outOfRangeParam((*string)(params.OutOfRange))
+	if !ok {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidParameter, "Invalid input: out_of_range must be one of reject, delete")
 	}
 
-	return spec.GetTripsTripIDActivitiesJSON200Response(response)
-}
-
-// Create a trip activity.
-// (POST /trips/{tripId}/activities)
-func (api *API) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
+	data, err := io.ReadAll(r.Body)
 	if err != nil {
-		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "invalid uuid"})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
 	}
 
-	var body spec.PostTripsTripIDActivitiesJSONBody
-	err = json.NewDecoder(r.Body).Decode(&body)
+	// In a merge patch null removes a field, but every trip field is required.
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
 	if err != nil {
-		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Invalid JSON"})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+	for name, value := range fields {
+		if string(value) == "null" {
+			return api.problem(w, r, http.StatusUnprocessableEntity, codeValidationFailed, "Invalid input: "+name+" can't be removed")
+		}
 	}
 
-	err = api.validator.Struct(body)
+	var patch spec.PatchTripRequest
+	err = json.Unmarshal(data, &patch)
 	if err != nil {
-		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Invalid input: " + err.Error()})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
 	}
 
-	actID, err := api.store.CreateActivity(r.Context(),
-		pgstore.CreateActivityParams{
-			TripID:   id,
-			Title:    body.Title,
-			OccursAt: pgtype.Timestamp{Valid: true, Time: body.OccursAt},
-		},
-	)
+	err = api.validator.Struct(patch)
 	if err != nil {
-		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Failed to create activity for trip, try again"})
+		return api.invalidInput(w, r, err)
 	}
 
-	return spec.PostTripsTripIDActivitiesJSON201Response(spec.CreateActivityResponse{ActivityID: actID.String()})
-}
-
-// Confirm a trip and send e-mail invitations.
-// (GET /trips/{tripId}/confirm)
-func (api *API) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
-	if err != nil {
-		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "invalid uuid"})
+	if !api.updateTrip(w, r, id, patch, deleteOutOfRange) {
+		return nil
 	}
 
+	return spec.PatchTripsTripIDJSON204Response(nil)
+}
+
+// updateTrip applies the fields set in patch to the trip, after checking the
+// If-Match header and the activities the new dates would leave out. It
+// reports whether it did, having written the problem response otherwise.
+//
+// Which changes matter beyond the trip itself:
+//   - New dates send a confirmed trip back to draft, and the owner gets a new
+//     confirmation link, as participants agreed to the old dates.
+//   - What participants see changed, per tripdiff.Diff, is e-mailed to the
+//     confirmed ones who did not opt out of it.
+//   - A new timezone alone does neither, the trip still happens at the same
+//     instants, but it can move activities to other trip days.
+func (api *API) updateTrip(w http.ResponseWriter, r *http.Request, id uuid.UUID, patch spec.PatchTripRequest, deleteOutOfRange bool) bool {
+	tripID := id.String()
 	trip, err := api.store.GetTrip(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Trip not found"})
+			api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+			return false
 		}
 
-		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", trip.ID.String()))
-		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Something went wrong finding trip, try again"})
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
+		return false
 	}
 
-	if trip.IsConfirmed {
-		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Trip already confirmed"})
+	if err := ifMatch(r, "trip", trip.Version); err != nil {
+		api.problem(w, r, err.status, err.code, err.detail)
+		return false
 	}
 
-	err = api.store.UpdateTrip(r.Context(),
-		pgstore.UpdateTripParams{
-			Destination: trip.Destination,
-			EndsAt:      trip.EndsAt,
-			StartsAt:    trip.StartsAt,
-			IsConfirmed: true,
-			ID:          id,
-		},
-	)
-	if err != nil {
-		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Failed to update trip for confirmation, try again"})
+	if err := tripLocked(trip.Status); err != nil {
+		api.problem(w, r, err.status, err.code, err.detail)
+		return false
 	}
 
-	participants, err := api.store.GetParticipants(r.Context(), id)
+	update := spec.UpdateTripRequest{
+		Destination: trip.Destination,
+		StartsAt:    trip.StartsAt.Time,
+		EndsAt:      trip.EndsAt.Time,
+		Timezone:    &trip.Timezone,
+	}
+	if patch.Destination != nil {
+		update.Destination = *patch.Destination
+	}
+	if patch.StartsAt != nil {
+		update.StartsAt = *patch.StartsAt
+	}
+	if patch.EndsAt != nil {
+		update.EndsAt = *patch.EndsAt
+	}
+	if patch.Timezone != nil {
+		update.Timezone = patch.Timezone
+	}
+
+	// A patch may only set one date, so the rules on both are checked on the
+	// trip it results in.
+	err = api.validator.Struct(update)
 	if err != nil {
-		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "Failed to get participants for trip, try again"})
+		api.invalidInput(w, r, err)
+		return false
 	}
 
-	go func() {
-		sem := make(chan struct{}, 1)
+	destinationChanged := update.Destination != trip.Destination
+	datesChanged := !update.StartsAt.Equal(trip.StartsAt.Time) || !update.EndsAt.Equal(trip.EndsAt.Time)
+	timezoneChanged := *update.Timezone != trip.Timezone
+	if !destinationChanged && !datesChanged && !timezoneChanged {
+		w.Header().Set("ETag", versionETag(trip.Version))
+		return true
+	}
 
-		for _, v := range participants {
-			sem <- struct{}{} // Acquire a slot
-			go func(email string) {
-				defer func() { <-sem }() // Release the slot
-				err := api.mailer.SendInviteToTripEmail(id, email)
-				if err != nil {
-					api.logger.Error(
-						"failed to send email on GetTripsTripIDConfirm",
-						zap.Error(err),
-						zap.String("trip_id", tripID),
-					)
-				}
-			}(string(v.Email))
+	// Activities left outside the new trip days block the update unless the
+	// caller asks for them to be deleted along with it.
+	var outOfRange []uuid.UUID
+	if datesChanged || timezoneChanged {
+		activities, err := api.store.GetTripActivities(r.Context(), id)
+		if err != nil {
+			api.logger.Error("Failed to get activities from trip", zap.Error(err), zap.String("trip_id", tripID))
+			api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to update trip, try again")
+			return false
 		}
 
-		// Wait for all goroutines to finish
-		for i := 0; i < cap(sem); i++ {
-			sem <- struct{}{}
+		loc := pgstore.Location(*update.Timezone)
+		for _, act := range activities {
+			if !withinTrip(act.OccursAt.Time, update.StartsAt, update.EndsAt, loc) {
+				outOfRange = append(outOfRange, act.ID)
+			}
 		}
-	}()
-
-	return spec.GetTripsTripIDConfirmJSON204Response(nil)
-}
-
-// Invite someone to the trip.
-// (POST /trips/{tripId}/invites)
-func (api *API) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
-	if err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "invalid uuid"})
 	}
 
-	var body spec.PostTripsTripIDInvitesJSONBody
-	err = json.NewDecoder(r.Body).Decode(&body)
-	if err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Invalid JSON"})
+	if len(outOfRange) > 0 && !deleteOutOfRange {
+		api.problem(w, r, http.StatusConflict, codeActivitiesOutOfRange,
+			fmt.Sprintf("%d activities fall outside the new trip dates, move them first or set out_of_range=delete", len(outOfRange)))
+		return false
 	}
 
-	err = api.validator.Struct(body)
-	if err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Invalid input: " + err.Error()})
+	arg := pgstore.UpdateTripParams{
+		Destination: pgText(patch.Destination),
+		Timezone:    pgText(patch.Timezone),
+		ID:          id,
+		Version:     trip.Version,
+	}
+	if patch.StartsAt != nil {
+		arg.StartsAt = pgtype.Timestamptz{Valid: true, Time: *patch.StartsAt}
+	}
+	if patch.EndsAt != nil {
+		arg.EndsAt = pgtype.Timestamptz{Valid: true, Time: *patch.EndsAt}
+	}
+	resetConfirmation := datesChanged && trip.Status == tripConfirmed
+	if resetConfirmation {
+		arg.Status = pgtype.Text{Valid: true, String: tripDraft}
 	}
 
-	_, err = api.store.InviteParticipantToTrip(r.Context(),
-		pgstore.InviteParticipantToTripParams{
-			TripID: id,
-			Email:  string(body.Email),
+	before := pgstore.Trip{
+		ID:          trip.ID,
+		Destination: trip.Destination,
+		StartsAt:    trip.StartsAt,
+		EndsAt:      trip.EndsAt,
+		OwnerID:     trip.OwnerID,
+		Status:      trip.Status,
+		Timezone:    trip.Timezone,
+		Version:     trip.Version,
+	}
+	updated, err := api.store.RescheduleTrip(r.Context(), api.pool, arg, outOfRange,
+		func(updated pgstore.Trip, participants []pgstore.GetParticipantsRow) ([]pgstore.EnqueueEmailParams, error) {
+			return tripUpdateEmails(before, updated, trip.OwnerEmail, resetConfirmation, participants)
 		},
 	)
 	if err != nil {
-		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "Failed to invite to trip, try again"})
+		// Someone else changed the trip between reading and updating it.
+		if errors.Is(err, pgx.ErrNoRows) {
+			err := versionMismatch("trip")
+			api.problem(w, r, err.status, err.code, err.detail)
+			return false
+		}
+
+		api.logger.Error("Failed to update trip", zap.Error(err), zap.String("trip_id", tripID))
+		api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to update trip, try again")
+		return false
 	}
 
-	go func() {
-		err := api.mailer.SendInviteToTripEmail(id, string(body.Email))
+	w.Header().Set("ETag", versionETag(updated.Version))
+	return true
+}
+
+// tripUpdateEmails returns the e-mails that go out with an update of the trip:
+// a new confirmation link for the owner when the update reset the
+// confirmation, and what changed for the participants who confirmed they are
+// coming, unless they opted out of it.
+func tripUpdateEmails(before, after pgstore.Trip, ownerEmail string, resetConfirmation bool, participants []pgstore.GetParticipantsRow) ([]pgstore.EnqueueEmailParams, error) {
+	var emails []pgstore.EnqueueEmailParams
+	if resetConfirmation {
+		e, err := emailParams(pgstore.EmailConfirmTrip, after.ID, uuid.Nil, ownerEmail, struct{}{})
 		if err != nil {
-			api.logger.Error(
-				"failed to send email on PostTripsTripIDInvites",
-				zap.Error(err),
-				zap.String("trip_id", tripID),
-			)
+			return nil, err
 		}
-	}()
+		emails = append(emails, e)
+	}
 
-	return spec.PostTripsTripIDInvitesJSON201Response(nil)
-}
+	changes := tripdiff.Diff(before, after)
+	if len(changes) == 0 {
+		return emails, nil
+	}
 
-// Get a trip links.
-// (GET /trips/{tripId}/links)
-func (api *API) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
-	if err != nil {
-		return spec.GetTripsTripIDLinksJSON400Response(spec.Error{Message: "invalid uuid"})
+	for _, p := range participants {
+		if p.RsvpStatus != rsvpConfirmed || !p.NotifyChanges {
+			continue
+		}
+
+		e, err := emailParams(pgstore.EmailTripUpdated, after.ID, p.ID, p.Email, tripUpdatedPayload{Changes: changes})
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, e)
 	}
 
-	links, err := api.store.GetTripLinks(r.Context(), id)
+	return emails, nil
+}
+
+// editableTrip returns the trip, or the problem to reply with when it is not
+// found or its status no longer allows changes to it, its activities or its
+// links.
+func (api *API) editableTrip(ctx context.Context, id uuid.UUID) (pgstore.GetTripRow, *apiError) {
+	trip, err := api.store.GetTrip(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDLinksJSON400Response(spec.Error{Message: "Links not found"})
+			return pgstore.GetTripRow{}, &apiError{http.StatusNotFound, codeTripNotFound, "Trip not found"}
 		}
 
-		api.logger.Error("Failed to get links from trip", zap.Error(err), zap.String("trip_id", id.String()))
-		return spec.GetTripsTripIDLinksJSON400Response(spec.Error{Message: "Something went wrong finding links from trip, try again"})
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", id.String()))
+		return pgstore.GetTripRow{}, &apiError{http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again"}
 	}
 
-	var response spec.GetLinksResponse
-	for _, l := range links {
-		response.Links = append(response.Links, spec.GetLinksResponseArray{
-			ID:    l.ID.String(),
-			Title: l.Title,
-			URL:   l.Url,
-		},
-		)
+	if err := tripLocked(trip.Status); err != nil {
+		return pgstore.GetTripRow{}, err
 	}
 
-	return spec.GetTripsTripIDLinksJSON200Response(response)
+	return trip, nil
 }
 
-// Create a trip link.
-// (POST /trips/{tripId}/links)
-func (api *API) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
-	id, err := uuid.Parse(tripID)
-	if err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "invalid uuid"})
+// outOfRangeParam reports whether the out_of_range query parameter asks for
+// activities outside the new trip dates to be deleted, and whether it is valid.
+func outOfRangeParam(value *string) (bool, bool) {
+	if value == nil {
+		return false, true
 	}
 
-	var body spec.PostTripsTripIDLinksJSONBody
-	err = json.NewDecoder(r.Body).Decode(&body)
-	if err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "Invalid JSON"})
+	switch *value {
+	case "reject":
+		return false, true
+	case "delete":
+		return true, true
+	default:
+		return false, false
 	}
+}
 
-	err = api.validator.Struct(body)
+// Delete a trip.
+// (DELETE /trips/{tripId})
+func (api *API) DeleteTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "Invalid input: " + err.Error()})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
 	}
 
-	linkID, err := api.store.CreateTripLink(r.Context(),
-		pgstore.CreateTripLinkParams{
-			TripID: id,
-			Title:  body.Title,
-			Url:    body.URL,
-		},
-	)
+	deleted, err := api.store.DeleteTrip(r.Context(), id)
 	if err != nil {
-		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "Failed to add link to trip, try again"})
+		api.logger.Error("Failed to delete trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to delete trip, try again")
 	}
 
-	return spec.PostTripsTripIDLinksJSON201Response(spec.CreateLinkResponse{LinkID: linkID.String()})
+	if deleted == 0 {
+		return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+	}
+
+	return spec.DeleteTripsTripIDJSON204Response(nil)
 }
 
-// Get a trip participants.
-// (GET /trips/{tripId}/participants)
-func (api *API) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+// Cancel a trip and notify its participants.
+// (POST /trips/{tripId}/cancel)
+func (api *API) PostTripsTripIDCancel(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
 	id, err := uuid.Parse(tripID)
 	if err != nil {
-		return spec.GetTripsTripIDParticipantsJSON400Response(spec.Error{Message: "invalid uuid"})
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
 	}
 
-	participants, err := api.store.GetParticipants(r.Context(), id)
+	trip, err := api.store.GetTrip(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return spec.GetTripsTripIDParticipantsJSON400Response(spec.Error{Message: "Participants not found"})
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
 		}
 
-		api.logger.Error("Failed to get participants from trip", zap.Error(err), zap.String("trip_id", id.String()))
-		return spec.GetTripsTripIDParticipantsJSON400Response(spec.Error{Message: "Something went wrong finding participants from trip, try again"})
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
 	}
 
-	var response spec.GetTripParticipantsResponse
-	for _, p := range participants {
-		response.Participants = append(response.Participants, spec.GetTripParticipantsResponseArray{
-			Email:       types.Email(p.Email),
-			ID:          p.ID.String(),
-			IsConfirmed: p.IsConfirmed,
+	if !canTransition(trip.Status, tripCancelled) {
+		return api.problem(w, r, http.StatusConflict, codeTripStatusConflict, "Trip is "+trip.Status+" and can no longer be cancelled")
+	}
+
+	updated, err := api.store.CancelTrip(r.Context(), api.pool,
+		pgstore.UpdateTripStatusParams{
+			ToStatus:   tripCancelled,
+			ID:         id,
+			FromStatus: trip.Status,
 		},
-		)
+	)
+	if err != nil {
+		api.logger.Error("Failed to cancel trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to cancel trip, try again")
 	}
 
-	return spec.GetTripsTripIDParticipantsJSON200Response(response)
+	// The status changed since it was read.
+	if updated == 0 {
+		return api.problem(w, r, http.StatusConflict, codeTripStatusConflict, "Trip status changed meanwhile, reload it and try again")
+	}
+
+	return spec.PostTripsTripIDCancelJSON204Response(nil)
+}
+
+// Mark a trip as completed or archived.
+// (PUT /trips/{tripId}/status)
+func (api *API) PutTripsTripIDStatus(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PutTripsTripIDStatusJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	status := body.Status.ToValue()
+	if status != tripCompleted && status != tripArchived {
+		return api.problem(w, r, http.StatusUnprocessableEntity, codeValidationFailed, "Invalid input: status must be completed or archived")
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+		}
+
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
+	}
+
+	if err := ifMatch(r, "trip", trip.Version); err != nil {
+		return api.problem(w, r, err.status, err.code, err.detail)
+	}
+
+	if !canTransition(trip.Status, status) {
+		return api.problem(w, r, http.StatusConflict, codeTripStatusConflict, "Trip is "+trip.Status+" and can't be "+status)
+	}
+
+	updated, err := api.store.UpdateTripStatus(r.Context(),
+		pgstore.UpdateTripStatusParams{
+			ToStatus:   status,
+			ID:         id,
+			FromStatus: trip.Status,
+		},
+	)
+	if err != nil {
+		api.logger.Error("Failed to update trip status", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to update trip status, try again")
+	}
+
+	// The status changed since it was read.
+	if updated == 0 {
+		return api.problem(w, r, http.StatusConflict, codeTripStatusConflict, "Trip status changed meanwhile, reload it and try again")
+	}
+
+	return spec.PutTripsTripIDStatusJSON204Response(nil)
+}
+
+// Get a trip activities.
+// (GET /trips/{tripId}/activities)
+func (api *API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+		}
+
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
+	}
+
+	activities, err := api.store.GetTripActivities(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get activities from trip", zap.Error(err), zap.String("trip_id", id.String()))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding activities from trip, try again")
+	}
+
+	response := spec.GetTripActivitiesResponse{
+		Activities: agenda(trip.StartsAt.Time, trip.EndsAt.Time, activities, pgstore.Location(trip.Timezone), time.Now()),
+	}
+
+	if notModified(w, r, bodyETag(response)) {
+		return nil
+	}
+
+	return spec.GetTripsTripIDActivitiesJSON200Response(response)
+}
+
+// Create a trip activity.
+// (POST /trips/{tripId}/activities)
+func (api *API) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PostTripsTripIDActivitiesJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	trip, perr := api.editableTrip(r.Context(), id)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	err = api.validator.Struct(activityDate{OccursAt: body.OccursAt, Trip: trip})
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	actID, err := api.store.CreateActivity(r.Context(),
+		pgstore.CreateActivityParams{
+			TripID:   id,
+			Title:    body.Title,
+			OccursAt: pgtype.Timestamptz{Valid: true, Time: body.OccursAt},
+			Timezone: pgText(body.Timezone),
+		},
+	)
+	if err != nil {
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to create activity for trip, try again")
+	}
+
+	return spec.PostTripsTripIDActivitiesJSON201Response(spec.CreateActivityResponse{ActivityID: actID.String()})
+}
+
+// Update a trip activity.
+// (PUT /trips/{tripId}/activities/{activityId})
+func (api *API) PutTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	actID, err := uuid.Parse(activityID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PutTripsTripIDActivitiesActivityIDJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	trip, perr := api.editableTrip(r.Context(), id)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	err = api.validator.Struct(activityDate{OccursAt: body.OccursAt, Trip: trip})
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	activity, err := api.store.GetTripActivity(r.Context(), pgstore.GetTripActivityParams{ID: actID, TripID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeActivityNotFound, "Activity not found")
+		}
+
+		api.logger.Error("Failed to get activity", zap.Error(err), zap.String("activity_id", activityID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding activity, try again")
+	}
+
+	if err := ifMatch(r, "activity", activity.Version); err != nil {
+		return api.problem(w, r, err.status, err.code, err.detail)
+	}
+
+	version, err := api.store.UpdateActivity(r.Context(),
+		pgstore.UpdateActivityParams{
+			Title:    body.Title,
+			OccursAt: pgtype.Timestamptz{Valid: true, Time: body.OccursAt},
+			Timezone: pgText(body.Timezone),
+			ID:       actID,
+			TripID:   id,
+			Version:  activity.Version,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err := versionMismatch("activity")
+			return api.problem(w, r, err.status, err.code, err.detail)
+		}
+
+		api.logger.Error("Failed to update activity", zap.Error(err), zap.String("activity_id", activityID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to update activity, try again")
+	}
+
+	w.Header().Set("ETag", versionETag(version))
+	return spec.PutTripsTripIDActivitiesActivityIDJSON204Response(nil)
+}
+
+// Partially update a trip activity.
+// (PATCH /trips/{tripId}/activities/{activityId})
+func (api *API) PatchTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	actID, err := uuid.Parse(activityID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PatchTripsTripIDActivitiesActivityIDJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	trip, perr := api.editableTrip(r.Context(), id)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	activity, err := api.store.GetTripActivity(r.Context(), pgstore.GetTripActivityParams{ID: actID, TripID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeActivityNotFound, "Activity not found")
+		}
+
+		api.logger.Error("Failed to get activity", zap.Error(err), zap.String("activity_id", activityID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding activity, try again")
+	}
+
+	if err := ifMatch(r, "activity", activity.Version); err != nil {
+		return api.problem(w, r, err.status, err.code, err.detail)
+	}
+
+	if body.Title != nil {
+		activity.Title = *body.Title
+	}
+	if body.OccursAt != nil {
+		activity.OccursAt = pgtype.Timestamptz{Valid: true, Time: *body.OccursAt}
+	}
+	if body.Timezone != nil {
+		activity.Timezone = pgText(body.Timezone)
+	}
+
+	if body.OccursAt != nil {
+		err = api.validator.Struct(activityDate{OccursAt: activity.OccursAt.Time, Trip: trip})
+		if err != nil {
+			return api.invalidInput(w, r, err)
+		}
+	}
+
+	version, err := api.store.UpdateActivity(r.Context(),
+		pgstore.UpdateActivityParams{
+			Title:    activity.Title,
+			OccursAt: activity.OccursAt,
+			Timezone: activity.Timezone,
+			ID:       actID,
+			TripID:   id,
+			Version:  activity.Version,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err := versionMismatch("activity")
+			return api.problem(w, r, err.status, err.code, err.detail)
+		}
+
+		api.logger.Error("Failed to update activity", zap.Error(err), zap.String("activity_id", activityID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to update activity, try again")
+	}
+
+	w.Header().Set("ETag", versionETag(version))
+	return spec.PatchTripsTripIDActivitiesActivityIDJSON204Response(nil)
+}
+
+// Delete a trip activity.
+// (DELETE /trips/{tripId}/activities/{activityId})
+func (api *API) DeleteTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	actID, err := uuid.Parse(activityID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	_, perr := api.editableTrip(r.Context(), id)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	deleted, err := api.store.DeleteActivity(r.Context(), pgstore.DeleteActivityParams{ID: actID, TripID: id})
+	if err != nil {
+		api.logger.Error("Failed to delete activity", zap.Error(err), zap.String("activity_id", activityID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to delete activity, try again")
+	}
+
+	if deleted == 0 {
+		return api.problem(w, r, http.StatusNotFound, codeActivityNotFound, "Activity not found")
+	}
+
+	return spec.DeleteTripsTripIDActivitiesActivityIDJSON204Response(nil)
+}
+
+// Show the page that confirms a trip.
+// (GET /trips/{tripId}/confirm)
+func (api *API) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string, params spec.GetTripsTripIDConfirmParams) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	_, perr := api.verifyLinkToken(params.Token, auth.PurposeConfirmTrip, id, "Confirmation")
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+		}
+
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
+	}
+
+	return api.writeActionPage(w, r, actionPage{
+		Title:   "Confirm your trip",
+		Message: "Confirm your trip to " + trip.Destination + "? The people you invited get their invites once you do.",
+		Button:  "Confirm trip",
+		Done:    "Your trip is confirmed and the invites are on their way.",
+	})
+}
+
+// Confirm a trip and send e-mail invitations.
+// (POST /trips/{tripId}/confirm)
+func (api *API) PostTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string, params spec.PostTripsTripIDConfirmParams) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	claims, perr := api.verifyLinkToken(params.Token, auth.PurposeConfirmTrip, id, "Confirmation")
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+		}
+
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
+	}
+
+	if trip.Status == tripConfirmed {
+		return api.problem(w, r, http.StatusConflict, codeTripStatusConflict, "Trip already confirmed")
+	}
+
+	if !canTransition(trip.Status, tripConfirmed) {
+		return api.problem(w, r, http.StatusConflict, codeTripStatusConflict, "Trip is "+trip.Status+" and can no longer be confirmed")
+	}
+
+	updated, err := api.store.ConfirmTrip(r.Context(), api.pool,
+		pgstore.UpdateTripStatusParams{
+			ToStatus:   tripConfirmed,
+			ID:         id,
+			FromStatus: trip.Status,
+		},
+		claims.ID,
+	)
+	if err != nil {
+		if errors.Is(err, pgstore.ErrTokenUsed) {
+			return api.problem(w, r, http.StatusConflict, codeTokenUsed, "Confirmation link already used")
+		}
+
+		api.logger.Error("Failed to confirm trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to update trip for confirmation, try again")
+	}
+
+	// The status changed since it was read.
+	if updated == 0 {
+		return api.problem(w, r, http.StatusConflict, codeTripStatusConflict, "Trip status changed meanwhile, reload it and try again")
+	}
+
+	return spec.PostTripsTripIDConfirmJSON204Response(nil)
+}
+
+// Invite people to the trip.
+// (POST /trips/{tripId}/invites)
+func (api *API) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PostTripsTripIDInvitesJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+		}
+
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
+	}
+
+	response := spec.InviteParticipantResponse{Results: []spec.InviteParticipantResult{}}
+	seen := make(map[string]bool, len(body.Emails))
+	for _, e := range body.Emails {
+		email := pgstore.NormalizeEmail(e)
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+
+		if api.validator.Var(email, "required,email") != nil {
+			response.Results = append(response.Results, spec.InviteParticipantResult{Email: e, Status: spec.InviteStatusInvalid})
+			continue
+		}
+
+		userID, err := api.store.UpsertUser(r.Context(), pgstore.UpsertUserParams{Email: email})
+		if err != nil {
+			api.logger.Error("Failed to upsert user", zap.Error(err))
+			return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to invite to trip, try again")
+		}
+
+		if userID == trip.OwnerID {
+			response.Results = append(response.Results, spec.InviteParticipantResult{Email: email, Status: spec.InviteStatusAlreadyInvited})
+			continue
+		}
+
+		participantID, err := api.store.InviteParticipant(r.Context(), api.pool,
+			pgstore.InviteParticipantToTripParams{
+				TripID: id,
+				UserID: userID,
+			},
+			email,
+		)
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				api.logger.Error("Failed to invite participant", zap.Error(err), zap.String("trip_id", tripID))
+				return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to invite to trip, try again")
+			}
+
+			participant, err := api.store.GetTripParticipantByUser(r.Context(),
+				pgstore.GetTripParticipantByUserParams{
+					TripID: id,
+					UserID: userID,
+				},
+			)
+			if err != nil {
+				api.logger.Error("Failed to get participant", zap.Error(err), zap.String("trip_id", tripID))
+				return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to invite to trip, try again")
+			}
+
+			pID := participant.ID.String()
+			response.Results = append(response.Results, spec.InviteParticipantResult{Email: email, Status: spec.InviteStatusAlreadyInvited, ParticipantID: &pID})
+			continue
+		}
+
+		pID := participantID.String()
+		response.Results = append(response.Results, spec.InviteParticipantResult{Email: email, Status: spec.InviteStatusCreated, ParticipantID: &pID})
+	}
+
+	return spec.PostTripsTripIDInvitesJSON200Response(response)
+}
+
+// Get a trip links.
+// (GET /trips/{tripId}/links)
+func (api *API) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	links, err := api.store.GetTripLinks(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeLinkNotFound, "Links not found")
+		}
+
+		api.logger.Error("Failed to get links from trip", zap.Error(err), zap.String("trip_id", id.String()))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding links from trip, try again")
+	}
+
+	response := spec.GetLinksResponse{Links: linksResponse(links)}
+	if notModified(w, r, bodyETag(response)) {
+		return nil
+	}
+
+	return spec.GetTripsTripIDLinksJSON200Response(response)
+}
+
+func linksResponse(links []pgstore.Link) []spec.GetLinksResponseArray {
+	var response []spec.GetLinksResponseArray
+	for _, l := range links {
+		response = append(response, spec.GetLinksResponseArray{
+			ID:       l.ID.String(),
+			Title:    l.Title,
+			URL:      l.Url,
+			Position: int(l.Position),
+			Version:  int(l.Version),
+		},
+		)
+	}
+
+	return response
+}
+
+// Create a trip link.
+// (POST /trips/{tripId}/links)
+func (api *API) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PostTripsTripIDLinksJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	_, perr := api.editableTrip(r.Context(), id)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	linkID, err := api.store.CreateTripLink(r.Context(),
+		pgstore.CreateTripLinkParams{
+			TripID: id,
+			Title:  body.Title,
+			Url:    body.URL,
+		},
+	)
+	if err != nil {
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to add link to trip, try again")
+	}
+
+	return spec.PostTripsTripIDLinksJSON201Response(spec.CreateLinkResponse{LinkID: linkID.String()})
+}
+
+// Update a trip link.
+// (PUT /trips/{tripId}/links/{linkId})
+func (api *API) PutTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request, tripID string, linkID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	lID, err := uuid.Parse(linkID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PutTripsTripIDLinksLinkIDJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	_, perr := api.editableTrip(r.Context(), id)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	link, err := api.store.GetTripLink(r.Context(), pgstore.GetTripLinkParams{ID: lID, TripID: id})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeLinkNotFound, "Link not found")
+		}
+
+		api.logger.Error("Failed to get link", zap.Error(err), zap.String("link_id", linkID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding link, try again")
+	}
+
+	if err := ifMatch(r, "link", link.Version); err != nil {
+		return api.problem(w, r, err.status, err.code, err.detail)
+	}
+
+	version, err := api.store.UpdateLink(r.Context(),
+		pgstore.UpdateLinkParams{
+			Title:   body.Title,
+			Url:     body.URL,
+			ID:      lID,
+			TripID:  id,
+			Version: link.Version,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err := versionMismatch("link")
+			return api.problem(w, r, err.status, err.code, err.detail)
+		}
+
+		api.logger.Error("Failed to update link", zap.Error(err), zap.String("link_id", linkID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to update link, try again")
+	}
+
+	w.Header().Set("ETag", versionETag(version))
+	return spec.PutTripsTripIDLinksLinkIDJSON204Response(nil)
+}
+
+// Delete a trip link.
+// (DELETE /trips/{tripId}/links/{linkId})
+func (api *API) DeleteTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request, tripID string, linkID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	lID, err := uuid.Parse(linkID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	_, perr := api.editableTrip(r.Context(), id)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	deleted, err := api.store.DeleteLink(r.Context(), pgstore.DeleteLinkParams{ID: lID, TripID: id})
+	if err != nil {
+		api.logger.Error("Failed to delete link", zap.Error(err), zap.String("link_id", linkID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to delete link, try again")
+	}
+
+	if deleted == 0 {
+		return api.problem(w, r, http.StatusNotFound, codeLinkNotFound, "Link not found")
+	}
+
+	return spec.DeleteTripsTripIDLinksLinkIDJSON204Response(nil)
+}
+
+// Reorder a trip links.
+// (PUT /trips/{tripId}/links/order)
+func (api *API) PutTripsTripIDLinksOrder(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PutTripsTripIDLinksOrderJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	_, perr := api.editableTrip(r.Context(), id)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	links, err := api.store.GetTripLinks(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get links from trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding links from trip, try again")
+	}
+
+	// The order is of the whole list, so the precondition is on its ETag.
+	if err := ifMatchETag(r, "link list", bodyETag(spec.GetLinksResponse{Links: linksResponse(links)})); err != nil {
+		return api.problem(w, r, err.status, err.code, err.detail)
+	}
+
+	linkIDs := make([]uuid.UUID, len(body.LinkIds))
+	for i, l := range body.LinkIds {
+		linkIDs[i] = uuid.MustParse(l)
+	}
+
+	err = api.store.ReorderTripLinks(r.Context(), api.pool, id, linkIDs)
+	if err != nil {
+		if errors.Is(err, pgstore.ErrLinkOrderMismatch) {
+			return api.problem(w, r, http.StatusUnprocessableEntity, codeValidationFailed, "Invalid input: link_ids must list every link of the trip once")
+		}
+
+		api.logger.Error("Failed to reorder links", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to reorder links, try again")
+	}
+
+	return spec.PutTripsTripIDLinksOrderJSON204Response(nil)
+}
+
+// Get a trip participants.
+// (GET /trips/{tripId}/participants)
+func (api *API) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	participants, err := api.store.GetParticipants(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participants not found")
+		}
+
+		api.logger.Error("Failed to get participants from trip", zap.Error(err), zap.String("trip_id", id.String()))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding participants from trip, try again")
+	}
+
+	response := spec.GetTripParticipantsResponse{Participants: api.participantsResponse(participants)}
+	if notModified(w, r, bodyETag(response)) {
+		return nil
+	}
+
+	return spec.GetTripsTripIDParticipantsJSON200Response(response)
+}
+
+func (api *API) participantsResponse(participants []pgstore.GetParticipantsRow) []spec.GetTripParticipantsResponseArray {
+	var response []spec.GetTripParticipantsResponseArray
+	for _, p := range participants {
+		var role spec.ParticipantRole
+		err := role.FromValue(p.Role)
+		if err != nil {
+			api.logger.Error("Unknown participant role", zap.Error(err), zap.String("participant_id", p.ID.String()))
+		}
+
+		response = append(response, spec.GetTripParticipantsResponseArray{
+			Email:         types.Email(p.Email),
+			ID:            p.ID.String(),
+			IsConfirmed:   p.RsvpStatus == rsvpConfirmed,
+			Name:          textPtr(p.Name),
+			RsvpStatus:    rsvpStatus(p.RsvpStatus),
+			Role:          role,
+			NotifyChanges: p.NotifyChanges,
+		},
+		)
+	}
+
+	return response
+}
+
+// Remove a participant from a trip.
+// (DELETE /trips/{tripId}/participants/{participantId})
+func (api *API) DeleteTripsTripIDParticipantsParticipantID(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *spec.Response {
+	tID, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	deleted, err := api.store.DeleteParticipant(r.Context(), pgstore.DeleteParticipantParams{ID: id, TripID: tID})
+	if err != nil {
+		api.logger.Error("Failed to delete participant", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to remove participant, try again")
+	}
+
+	if deleted == 0 {
+		return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+	}
+
+	return spec.DeleteTripsTripIDParticipantsParticipantIDJSON204Response(nil)
+}
+
+// Resend the invite e-mail to a participant.
+// (POST /trips/{tripId}/participants/{participantId}/resend)
+func (api *API) PostTripsTripIDParticipantsParticipantIDResend(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *spec.Response {
+	tID, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), tID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+		}
+
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
+	}
+
+	if trip.Status != tripDraft && trip.Status != tripConfirmed {
+		return api.problem(w, r, http.StatusConflict, codeTripStatusConflict, "Trip is "+trip.Status+" and no longer sends invites")
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+		}
+
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding participant, try again")
+	}
+
+	if participant.TripID != tID {
+		return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+	}
+
+	if participant.RsvpStatus != rsvpPending {
+		return api.problem(w, r, http.StatusConflict, codeAlreadyAnswered, "Participant already "+participant.RsvpStatus)
+	}
+
+	now := time.Now()
+	marked, err := api.store.ResendInvite(r.Context(), api.pool,
+		pgstore.MarkParticipantInvitedParams{
+			InvitedAt:     pgtype.Timestamptz{Valid: true, Time: now},
+			ID:            id,
+			TripID:        tID,
+			InvitedBefore: pgtype.Timestamptz{Valid: true, Time: now.Add(-resendInviteInterval)},
+		},
+		participant.Email,
+	)
+	if err != nil {
+		api.logger.Error("Failed to mark participant invited", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to resend invite, try again")
+	}
+
+	if marked == 0 {
+		return api.problem(w, r, http.StatusTooManyRequests, codeInviteThrottled, "Invite was sent recently, try again later")
+	}
+
+	return spec.PostTripsTripIDParticipantsParticipantIDResendJSON204Response(nil)
+}
+
+// Change the role of a participant on a trip.
+// (PUT /trips/{tripId}/participants/{participantId}/role)
+func (api *API) PutTripsTripIDParticipantsParticipantIDRole(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *spec.Response {
+	tID, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PutTripsTripIDParticipantsParticipantIDRoleJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	if body.Role == spec.UnknownParticipantRole {
+		return api.problem(w, r, http.StatusUnprocessableEntity, codeValidationFailed, "Invalid input: role is required")
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+		}
+
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding participant, try again")
+	}
+
+	if participant.TripID != tID {
+		return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+	}
+
+	err = api.store.UpdateParticipantRole(r.Context(),
+		pgstore.UpdateParticipantRoleParams{
+			Role: body.Role.ToValue(),
+			ID:   id,
+		},
+	)
+	if err != nil {
+		api.logger.Error("Failed to update participant role", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to update participant role, try again")
+	}
+
+	return spec.PutTripsTripIDParticipantsParticipantIDRoleJSON204Response(nil)
+}
+
+// Choose whether a participant gets e-mails about changes to the trip.
+// (PUT /trips/{tripId}/participants/{participantId}/notifications)
+func (api *API) PutTripsTripIDParticipantsParticipantIDNotifications(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *spec.Response {
+	tID, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	var body spec.PutTripsTripIDParticipantsParticipantIDNotificationsJSONBody
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+		}
+
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding participant, try again")
+	}
+
+	if participant.TripID != tID {
+		return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+	}
+
+	userID, ok := api.authenticate(r)
+	if !ok {
+		return api.problem(w, r, http.StatusUnauthorized, codeUnauthorized, "Missing or invalid session token")
+	}
+
+	if participant.UserID != userID {
+		return api.problem(w, r, http.StatusForbidden, codeForbidden, "Only the participant can change their notifications")
+	}
+
+	_, err = api.store.UpdateParticipantNotifyChanges(r.Context(),
+		pgstore.UpdateParticipantNotifyChangesParams{
+			NotifyChanges: body.NotifyChanges,
+			ID:            id,
+		},
+	)
+	if err != nil {
+		api.logger.Error("Failed to update participant notifications", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to update participant notifications, try again")
+	}
+
+	return spec.PutTripsTripIDParticipantsParticipantIDNotificationsJSON204Response(nil)
+}
+
+// Get the delivery status of the e-mails sent to a participant.
+// (GET /trips/{tripId}/participants/{participantId}/emails)
+func (api *API) GetTripsTripIDParticipantsParticipantIDEmails(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *spec.Response {
+	tID, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+		}
+
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding participant, try again")
+	}
+
+	if participant.TripID != tID {
+		return api.problem(w, r, http.StatusNotFound, codeParticipantNotFound, "Participant not found")
+	}
+
+	emails, err := api.store.GetParticipantEmails(r.Context(), pgtype.UUID{Valid: true, Bytes: id})
+	if err != nil {
+		api.logger.Error("Failed to get participant e-mails", zap.Error(err), zap.String("participant_id", participantID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding e-mails, try again")
+	}
+
+	response := spec.GetParticipantEmailsResponse{Emails: []spec.EmailDelivery{}}
+	for _, e := range emails {
+		var kind spec.EmailKind
+		err := kind.FromValue(e.Kind)
+		if err != nil {
+			api.logger.Error("Unknown e-mail kind", zap.Error(err), zap.String("email_id", e.ID.String()))
+		}
+
+		var status spec.EmailStatus
+		err = status.FromValue(e.Status)
+		if err != nil {
+			api.logger.Error("Unknown e-mail status", zap.Error(err), zap.String("email_id", e.ID.String()))
+		}
+
+		delivery := spec.EmailDelivery{
+			ID:        e.ID.String(),
+			Kind:      kind,
+			Recipient: types.Email(e.Recipient),
+			Status:    status,
+			Attempts:  int(e.Attempts),
+			LastError: textPtr(e.LastError),
+			CreatedAt: e.CreatedAt.Time,
+			SentAt:    timePtr(e.SentAt),
+		}
+		if e.Status == pgstore.EmailPending {
+			delivery.NextAttemptAt = timePtr(e.NextAttemptAt)
+		}
+
+		response.Emails = append(response.Emails, delivery)
+	}
+
+	return spec.GetTripsTripIDParticipantsParticipantIDEmailsJSON200Response(response)
+}
+
+// Get the trip and its activities as an iCalendar feed.
+// (GET /trips/{tripId}/calendar.ics)
+func (api *API) GetTripsTripIDCalendarIcs(w http.ResponseWriter, r *http.Request, tripID string, params spec.GetTripsTripIDCalendarIcsParams) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+	}
+
+	token, err := uuid.Parse(params.Token)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidToken, "Invalid calendar link")
+	}
+
+	_, err = api.store.GetCalendarParticipant(r.Context(),
+		pgstore.GetCalendarParticipantParams{
+			TripID:        id,
+			CalendarToken: token,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+		}
+
+		api.logger.Error("Failed to get calendar participant", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding calendar, try again")
+	}
+
+	trip, err := api.store.GetTrip(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+		}
+
+		api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
+	}
+
+	activities, err := api.store.GetTripActivities(r.Context(), id)
+	if err != nil {
+		api.logger.Error("Failed to get trip activities", zap.Error(err), zap.String("trip_id", tripID))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding activities, try again")
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	// The token is the only thing guarding the feed, keep it out of caches.
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(ical.TripCalendar(trip, activities).Encode(time.Now()))
+	if err != nil {
+		api.logger.Warn("Failed to write calendar", zap.Error(err), zap.String("trip_id", tripID))
+	}
+
+	return nil
+}
+
+// Get the profile of the logged in user.
+// (GET /me)
+func (api *API) GetMe(w http.ResponseWriter, r *http.Request) *spec.Response {
+	userID, ok := api.authenticate(r)
+	if !ok {
+		return api.problem(w, r, http.StatusUnauthorized, codeUnauthorized, "Missing or invalid session token")
+	}
+
+	user, err := api.store.GetUser(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return api.problem(w, r, http.StatusNotFound, codeUserNotFound, "User not found")
+		}
+
+		api.logger.Error("Failed to get user", zap.Error(err), zap.String("user_id", userID.String()))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding user, try again")
+	}
+
+	response := spec.UserProfile{
+		ID:       user.ID.String(),
+		Email:    types.Email(user.Email),
+		Name:     textPtr(user.Name),
+		Locale:   textPtr(user.Locale),
+		Timezone: textPtr(user.Timezone),
+	}
+	if notModified(w, r, bodyETag(response)) {
+		return nil
+	}
+
+	return spec.GetMeJSON200Response(response)
+}
+
+// Update the profile of the logged in user.
+// (PUT /me)
+func (api *API) PutMe(w http.ResponseWriter, r *http.Request) *spec.Response {
+	userID, ok := api.authenticate(r)
+	if !ok {
+		return api.problem(w, r, http.StatusUnauthorized, codeUnauthorized, "Missing or invalid session token")
+	}
+
+	var body spec.PutMeJSONBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	err = api.store.UpdateUser(r.Context(),
+		pgstore.UpdateUserParams{
+			Name:     pgText(body.Name),
+			Locale:   pgText(body.Locale),
+			Timezone: pgText(body.Timezone),
+			ID:       userID,
+		},
+	)
+	if err != nil {
+		api.logger.Error("Failed to update user", zap.Error(err), zap.String("user_id", userID.String()))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong updating profile, try again")
+	}
+
+	return spec.PutMeJSON204Response(nil)
+}
+
+// Send a link to change the e-mail of the logged in user.
+// (POST /me/email)
+func (api *API) PostMeEmail(w http.ResponseWriter, r *http.Request) *spec.Response {
+	userID, ok := api.authenticate(r)
+	if !ok {
+		return api.problem(w, r, http.StatusUnauthorized, codeUnauthorized, "Missing or invalid session token")
+	}
+
+	var body spec.PostMeEmailJSONBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	// Whether someone already has the address is only checked once the link
+	// is used, so this doesn't tell which addresses are registered.
+	email := pgstore.NormalizeEmail(string(body.Email))
+	expiresAt := time.Now().Add(auth.EmailChangeTokenTTL)
+	err = api.store.RequestEmailChange(r.Context(), api.pool,
+		pgstore.InsertEmailChangeTokenParams{
+			UserID:    userID,
+			Email:     email,
+			ExpiresAt: pgtype.Timestamptz{Valid: true, Time: expiresAt},
+		},
+		func(tokenID uuid.UUID) (pgstore.EnqueueEmailParams, error) {
+			return emailParams(pgstore.EmailChangeEmail, uuid.Nil, uuid.Nil, email,
+				changeEmailPayload{TokenID: tokenID, UserID: userID, ExpiresAt: expiresAt},
+			)
+		},
+	)
+	if err != nil {
+		api.logger.Error("Failed to request email change", zap.Error(err), zap.String("user_id", userID.String()))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Failed to send e-mail change link, try again")
+	}
+
+	return spec.PostMeEmailJSON204Response(nil)
+}
+
+// Show the page that changes the e-mail of a user.
+// (GET /me/email/verify)
+func (api *API) GetMeEmailVerify(w http.ResponseWriter, r *http.Request, params spec.GetMeEmailVerifyParams) *spec.Response {
+	_, perr := api.verifyEmailChangeToken(params.Token)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	return api.writeActionPage(w, r, actionPage{
+		Title:   "Change your e-mail",
+		Message: "Make this address your Travel Planner e-mail?",
+		Button:  "Change e-mail",
+		Done:    "Your e-mail was changed, use it to log in from now on.",
+	})
+}
+
+// Change the e-mail of a user to the address the link was sent to.
+// (POST /me/email/verify)
+func (api *API) PostMeEmailVerify(w http.ResponseWriter, r *http.Request, params spec.PostMeEmailVerifyParams) *spec.Response {
+	claims, perr := api.verifyEmailChangeToken(params.Token)
+	if perr != nil {
+		return api.problem(w, r, perr.status, perr.code, perr.detail)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidToken, "Invalid e-mail change link")
+	}
+
+	_, err = api.store.ChangeUserEmail(r.Context(), api.pool,
+		pgstore.ConsumeEmailChangeTokenParams{
+			ID:     claims.ID,
+			UserID: userID,
+		},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgstore.ErrTokenUsed):
+			return api.problem(w, r, http.StatusConflict, codeTokenUsed, "E-mail change link already used")
+		case errors.Is(err, pgstore.ErrEmailTaken):
+			return api.problem(w, r, http.StatusConflict, codeEmailTaken, "E-mail already in use")
+		}
+
+		api.logger.Error("Failed to change user email", zap.Error(err), zap.String("user_id", userID.String()))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong changing e-mail, try again")
+	}
+
+	return spec.PostMeEmailVerifyJSON204Response(nil)
+}
+
+// List the trips the logged in user owns or is invited to.
+// (GET /me/trips)
+func (api *API) GetMeTrips(w http.ResponseWriter, r *http.Request, params spec.GetMeTripsParams) *spec.Response {
+	userID, ok := api.authenticate(r)
+	if !ok {
+		return api.problem(w, r, http.StatusUnauthorized, codeUnauthorized, "Missing or invalid session token")
+	}
+
+	arg := pgstore.ListUserTripsParams{UserID: userID}
+	if params.Filter != nil {
+		switch *params.Filter {
+		case "upcoming", "past", "unconfirmed":
+			arg.Filter = string(*params.Filter)
+		default:
+			return api.problem(w, r, http.StatusBadRequest, codeInvalidParameter, "Invalid input: filter must be one of upcoming, past, unconfirmed")
+		}
+	}
+
+	if params.Sort != nil {
+		switch *params.Sort {
+		case "asc":
+		case "desc":
+			arg.SortDesc = true
+		default:
+			return api.problem(w, r, http.StatusBadRequest, codeInvalidParameter, "Invalid input: sort must be one of asc, desc")
+		}
+	}
+
+	page, pageSize := 1, defaultPageSize
+	if params.Page != nil {
+		page = *params.Page
+	}
+	if params.PageSize != nil {
+		pageSize = *params.PageSize
+	}
+	if page < 1 || pageSize < 1 || pageSize > maxPageSize {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidParameter, "Invalid input: page must be at least 1 and page_size between 1 and 100")
+	}
+
+	// Ask for one extra trip to know whether there is a next page.
+	arg.PageLimit = int32(pageSize + 1)
+	arg.PageOffset = int32((page - 1) * pageSize)
+	trips, err := api.store.ListUserTrips(r.Context(), arg)
+	if err != nil {
+		api.logger.Error("Failed to list user trips", zap.Error(err), zap.String("user_id", userID.String()))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trips, try again")
+	}
+
+	response := spec.ListTripsResponse{
+		Trips:    []spec.GetTripDetailsResponseTripObj{},
+		Page:     page,
+		PageSize: pageSize,
+		HasMore:  len(trips) > pageSize,
+	}
+	for i, trip := range trips {
+		if i == pageSize {
+			break
+		}
+
+		loc := pgstore.Location(trip.Timezone)
+		response.Trips = append(response.Trips, spec.GetTripDetailsResponseTripObj{
+			ID:          trip.ID.String(),
+			Destination: trip.Destination,
+			StartsAt:    trip.StartsAt.Time.In(loc),
+			EndsAt:      trip.EndsAt.Time.In(loc),
+			Timezone:    trip.Timezone,
+			IsConfirmed: tripIsConfirmed(trip.Status),
+			Status:      tripStatus(trip.Status),
+			Version:     int(trip.Version),
+		})
+	}
+
+	if notModified(w, r, bodyETag(response)) {
+		return nil
+	}
+
+	return spec.GetMeTripsJSON200Response(response)
+}
+
+// Render an e-mail template without sending it.
+// (GET /admin/emails/{template}/preview)
+func (api *API) GetAdminEmailsTemplatePreview(w http.ResponseWriter, r *http.Request, template string, params spec.GetAdminEmailsTemplatePreviewParams) *spec.Response {
+	var tripID uuid.UUID
+	if params.TripID != nil {
+		id, err := uuid.Parse(*params.TripID)
+		if err != nil {
+			return api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+		}
+		tripID = id
+	}
+
+	var locale string
+	if params.Locale != nil {
+		locale = *params.Locale
+	}
+
+	rendered, err := api.mailer.Preview(r.Context(), template, tripID, locale)
+	if err != nil {
+		return api.emailPreviewProblem(w, r, err, template, tripID)
+	}
+
+	return spec.GetAdminEmailsTemplatePreviewJSON200Response(spec.EmailPreview{
+		Template: template,
+		Locale:   rendered.Locale,
+		Subject:  rendered.Subject,
+		Text:     rendered.Text,
+		HTML:     rendered.HTML,
+	})
+}
+
+// Send an e-mail template to an address.
+// (POST /admin/emails/{template}/test-send)
+func (api *API) PostAdminEmailsTemplateTestSend(w http.ResponseWriter, r *http.Request, template string) *spec.Response {
+	var body spec.PostAdminEmailsTemplateTestSendJSONBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidJSON, "Invalid JSON")
+	}
+
+	err = api.validator.Struct(body)
+	if err != nil {
+		return api.invalidInput(w, r, err)
+	}
+
+	var tripID uuid.UUID
+	if body.TripID != nil {
+		tripID = uuid.MustParse(*body.TripID)
+	}
+
+	var locale string
+	if body.Locale != nil {
+		locale = *body.Locale
+	}
+
+	err = api.mailer.SendPreview(r.Context(), template, tripID, locale, string(body.Email))
+	if err != nil {
+		return api.emailPreviewProblem(w, r, err, template, tripID)
+	}
+
+	return spec.PostAdminEmailsTemplateTestSendJSON204Response(nil)
+}
+
+// emailPreviewProblem writes the problem response for an error previewing or
+// test sending an e-mail template.
+func (api *API) emailPreviewProblem(w http.ResponseWriter, r *http.Request, err error, template string, tripID uuid.UUID) *spec.Response {
+	switch {
+	case errors.Is(err, email.ErrUnknownTemplate):
+		return api.problem(w, r, http.StatusNotFound, codeTemplateNotFound, "Template not found")
+	case errors.Is(err, email.ErrTripRequired):
+		return api.problem(w, r, http.StatusBadRequest, codeInvalidParameter, "Invalid input: trip_id is required for template "+template)
+	case errors.Is(err, pgx.ErrNoRows):
+		return api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+	case errors.Is(err, email.ErrSend):
+		api.logger.Warn("Failed to send test e-mail", zap.Error(err), zap.String("template", template))
+		return api.problem(w, r, http.StatusBadGateway, codeEmailFailed, "Mail server did not take the e-mail, try again")
+	}
+
+	api.logger.Error("Failed to render e-mail", zap.Error(err), zap.String("template", template), zap.String("trip_id", tripID.String()))
+	return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong rendering e-mail, try again")
+}
+
+func textPtr(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.String
+}
+
+func timePtr(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+func pgText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+
+	return pgtype.Text{Valid: true, String: *s}
 }
@@ -0,0 +1,417 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"server/internal/auth"
+	"server/internal/pgstore"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// role is the access level of the caller on a trip. Each role is allowed
+// everything the roles below it are.
+type role int
+
+const (
+	roleNone role = iota
+	roleViewer
+	roleParticipant
+	roleCoOrganizer
+	roleOwner
+)
+
+var roleNames = map[role]string{
+	roleViewer:      "viewer",
+	roleParticipant: "participant",
+	roleCoOrganizer: "co_organizer",
+	roleOwner:       "owner",
+}
+
+// participantRoles maps the participants.role column to its role.
+var participantRoles = map[string]role{
+	"viewer":       roleViewer,
+	"participant":  roleParticipant,
+	"co_organizer": roleCoOrganizer,
+}
+
+// routeRoles are the route patterns scoped to a trip and the minimum role
+// the caller needs on that trip to call them.
+var routeRoles = map[string]role{
+	"GET /trips/{tripId}":                                            roleViewer,
+	"GET /trips/{tripId}/activities":                                 roleViewer,
+	"GET /trips/{tripId}/links":                                      roleViewer,
+	"GET /trips/{tripId}/participants":                               roleViewer,
+	"POST /trips/{tripId}/links":                                     roleParticipant,
+	"PUT /trips/{tripId}/links/{linkId}":                             roleCoOrganizer,
+	"DELETE /trips/{tripId}/links/{linkId}":                          roleCoOrganizer,
+	"PUT /trips/{tripId}/links/order":                                roleCoOrganizer,
+	"POST /trips/{tripId}/activities":                                roleCoOrganizer,
+	"PUT /trips/{tripId}/activities/{activityId}":                    roleCoOrganizer,
+	"PATCH /trips/{tripId}/activities/{activityId}":                  roleCoOrganizer,
+	"DELETE /trips/{tripId}/activities/{activityId}":                 roleCoOrganizer,
+	"POST /trips/{tripId}/invites":                                   roleCoOrganizer,
+	"DELETE /trips/{tripId}/participants/{participantId}":            roleCoOrganizer,
+	"POST /trips/{tripId}/participants/{participantId}/resend":       roleCoOrganizer,
+	"GET /trips/{tripId}/participants/{participantId}/emails":        roleCoOrganizer,
+	"PUT /trips/{tripId}":                                            roleOwner,
+	"PATCH /trips/{tripId}":                                          roleOwner,
+	"DELETE /trips/{tripId}":                                         roleOwner,
+	"POST /trips/{tripId}/cancel":                                    roleOwner,
+	"PUT /trips/{tripId}/status":                                     roleOwner,
+	"PUT /trips/{tripId}/participants/{participantId}/role":          roleOwner,
+	"PUT /trips/{tripId}/participants/{participantId}/notifications": roleViewer,
+}
+
+// adminRoutes are the route patterns only admins may call.
+var adminRoutes = map[string]bool{
+	"GET /admin/emails/{template}/preview":    true,
+	"POST /admin/emails/{template}/test-send": true,
+}
+
+// Authorize rejects calls to trip routes unless the bearer session token
+// belongs to someone with a high enough role on the trip in the path, and
+// calls to admin routes unless it belongs to an admin.
+// routes must be the router spec.Handler registers its routes on, so the
+// route pattern can be resolved before the request reaches it.
+func (api *API) Authorize(routes chi.Routes) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.NewRouteContext()
+			if !routes.Match(rctx, r.Method, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			pattern := r.Method + " " + rctx.RoutePattern()
+			if adminRoutes[pattern] {
+				if api.authorizeAdmin(w, r) {
+					next.ServeHTTP(w, r)
+				}
+				return
+			}
+
+			required, ok := routeRoles[pattern]
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID, ok := api.authenticate(r)
+			if !ok {
+				api.problem(w, r, http.StatusUnauthorized, codeUnauthorized, "Missing or invalid session token")
+				return
+			}
+
+			tripID, err := uuid.Parse(rctx.URLParam("tripId"))
+			if err != nil {
+				api.problem(w, r, http.StatusBadRequest, codeInvalidID, "invalid uuid")
+				return
+			}
+
+			trip, err := api.store.GetTrip(r.Context(), tripID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					api.problem(w, r, http.StatusNotFound, codeTripNotFound, "Trip not found")
+					return
+				}
+
+				api.logger.Error("Failed to get trip", zap.Error(err), zap.String("trip_id", tripID.String()))
+				api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong finding trip, try again")
+				return
+			}
+
+			caller, err := api.tripRole(r.Context(), trip, userID)
+			if err != nil {
+				api.logger.Error("Failed to get caller role", zap.Error(err), zap.String("trip_id", tripID.String()))
+				api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong checking permissions, try again")
+				return
+			}
+
+			if caller == roleNone {
+				api.problem(w, r, http.StatusForbidden, codeForbidden, "You are not part of this trip")
+				return
+			}
+
+			if caller < required {
+				api.problem(w, r, http.StatusForbidden, codeForbidden,
+					"Your role on this trip is "+roleNames[caller]+", this requires "+roleNames[required]+" or above")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authorizeAdmin writes the problem response and returns false unless the
+// bearer session token belongs to an admin.
+func (api *API) authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	userID, ok := api.authenticate(r)
+	if !ok {
+		api.problem(w, r, http.StatusUnauthorized, codeUnauthorized, "Missing or invalid session token")
+		return false
+	}
+
+	user, err := api.store.GetUser(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			api.problem(w, r, http.StatusUnauthorized, codeUnauthorized, "Missing or invalid session token")
+			return false
+		}
+
+		api.logger.Error("Failed to get user", zap.Error(err), zap.String("user_id", userID.String()))
+		api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong checking permissions, try again")
+		return false
+	}
+
+	if !user.IsAdmin {
+		api.problem(w, r, http.StatusForbidden, codeForbidden, "This requires an admin")
+		return false
+	}
+
+	return true
+}
+
+// tripRole returns the role of the user on the trip, or roleNone when they
+// are neither the owner nor a participant.
+func (api *API) tripRole(ctx context.Context, trip pgstore.GetTripRow, userID uuid.UUID) (role, error) {
+	if trip.OwnerID == userID {
+		return roleOwner, nil
+	}
+
+	participant, err := api.store.GetTripParticipantByUser(ctx,
+		pgstore.GetTripParticipantByUserParams{
+			TripID: trip.ID,
+			UserID: userID,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return roleNone, nil
+		}
+
+		return roleNone, err
+	}
+
+	return participantRoles[participant.Role], nil
+}
+
+// authenticate returns the ID of the user the bearer session token belongs to.
+func (api *API) authenticate(r *http.Request) (uuid.UUID, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return uuid.UUID{}, false
+	}
+
+	claims, err := api.signer.Verify(token, auth.PurposeSession)
+	if err != nil {
+		return uuid.UUID{}, false
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.UUID{}, false
+	}
+
+	return userID, true
+}
+
+// verifyLinkToken returns the claims of the token from an e-mail link, or the
+// problem to reply with unless it was issued for purpose to subject. what
+// names the link in the problem.
+func (api *API) verifyLinkToken(token string, purpose auth.Purpose, subject uuid.UUID, what string) (auth.Claims, *apiError) {
+	claims, err := api.signer.Verify(token, purpose)
+	if err != nil || claims.Subject != subject.String() {
+		if errors.Is(err, auth.ErrExpiredToken) {
+			return auth.Claims{}, &apiError{http.StatusBadRequest, codeTokenExpired, what + " link expired"}
+		}
+
+		return auth.Claims{}, &apiError{http.StatusBadRequest, codeInvalidToken, "Invalid " + strings.ToLower(what) + " link"}
+	}
+
+	return claims, nil
+}
+
+// verifyLoginToken checks the token of a login link, returning the problem to
+// reply with when it is not valid.
+func (api *API) verifyLoginToken(token string) (auth.Claims, *apiError) {
+	claims, err := api.signer.Verify(token, auth.PurposeLogin)
+	if err != nil {
+		if errors.Is(err, auth.ErrExpiredToken) {
+			return auth.Claims{}, &apiError{http.StatusBadRequest, codeTokenExpired, "Login link expired, request a new one"}
+		}
+
+		return auth.Claims{}, &apiError{http.StatusBadRequest, codeInvalidToken, "Invalid login link"}
+	}
+
+	return claims, nil
+}
+
+// verifyEmailChangeToken checks the token of an e-mail change link, returning
+// the problem to reply with when it is not valid.
+func (api *API) verifyEmailChangeToken(token string) (auth.Claims, *apiError) {
+	claims, err := api.signer.Verify(token, auth.PurposeChangeEmail)
+	if err != nil {
+		if errors.Is(err, auth.ErrExpiredToken) {
+			return auth.Claims{}, &apiError{http.StatusBadRequest, codeTokenExpired, "E-mail change link expired, ask for a new one"}
+		}
+
+		return auth.Claims{}, &apiError{http.StatusBadRequest, codeInvalidToken, "Invalid e-mail change link"}
+	}
+
+	return claims, nil
+}
+
+// confirmationToken issues a signed, single-use token that confirms the trip,
+// or answers the invite of the participant when participantID is set.
+func (api *API) confirmationToken(ctx context.Context, tripID uuid.UUID, participantID pgtype.UUID) (string, error) {
+	expiresAt := time.Now().Add(auth.ConfirmationTokenTTL)
+	tokenID, err := api.store.InsertConfirmationToken(ctx,
+		pgstore.InsertConfirmationTokenParams{
+			TripID:        tripID,
+			ParticipantID: participantID,
+			ExpiresAt:     pgtype.Timestamptz{Valid: true, Time: expiresAt},
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("api: failed to insert confirmation token: %w", err)
+	}
+
+	return api.signConfirmationToken(tokenID, tripID, participantID, expiresAt)
+}
+
+// signConfirmationToken signs the stored confirmation token tokenID.
+func (api *API) signConfirmationToken(tokenID uuid.UUID, tripID uuid.UUID, participantID pgtype.UUID, expiresAt time.Time) (string, error) {
+	claims := auth.Claims{
+		ID:        tokenID,
+		Subject:   tripID.String(),
+		Purpose:   auth.PurposeConfirmTrip,
+		ExpiresAt: expiresAt,
+	}
+	if participantID.Valid {
+		claims.Subject = uuid.UUID(participantID.Bytes).String()
+		claims.Purpose = auth.PurposeConfirmParticipant
+	}
+
+	token, err := api.signer.Sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("api: failed to sign confirmation token: %w", err)
+	}
+
+	return token, nil
+}
+
+// loginLink returns the e-mail link that logs the user in, for a login token
+// already stored under tokenID.
+func (api *API) loginLink(tokenID uuid.UUID, email string, expiresAt time.Time) (string, error) {
+	token, err := api.signer.Sign(auth.Claims{
+		ID:        tokenID,
+		Subject:   email,
+		Purpose:   auth.PurposeLogin,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", fmt.Errorf("api: failed to sign login token: %w", err)
+	}
+
+	return api.baseURL + "/auth/login/verify?token=" + url.QueryEscape(token), nil
+}
+
+// emailChangeLink returns the e-mail link that changes the e-mail of the user,
+// for an e-mail change token already stored under tokenID.
+func (api *API) emailChangeLink(tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) (string, error) {
+	token, err := api.signer.Sign(auth.Claims{
+		ID:        tokenID,
+		Subject:   userID.String(),
+		Purpose:   auth.PurposeChangeEmail,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", fmt.Errorf("api: failed to sign email change token: %w", err)
+	}
+
+	return api.baseURL + "/me/email/verify?token=" + url.QueryEscape(token), nil
+}
+
+// confirmationLink returns the e-mail link the owner uses to confirm the trip.
+func (api *API) confirmationLink(ctx context.Context, tripID uuid.UUID) (string, error) {
+	token, err := api.confirmationToken(ctx, tripID, pgtype.UUID{})
+	if err != nil {
+		return "", err
+	}
+
+	return api.baseURL + "/trips/" + tripID.String() + "/confirm?token=" + url.QueryEscape(token), nil
+}
+
+// inviteLink is the pair of e-mail links a participant uses to confirm or
+// decline the invite. Both carry the same token, so only the first one used
+// counts.
+type inviteLink struct {
+	confirm string
+	decline string
+}
+
+// inviteLinks returns the invite links of each of the participants by ID,
+// storing all of their tokens in a single statement.
+func (api *API) inviteLinks(ctx context.Context, tripID uuid.UUID, participantIDs []uuid.UUID) (map[uuid.UUID]inviteLink, error) {
+	expiresAt := time.Now().Add(auth.ConfirmationTokenTTL)
+	tokens, err := api.store.InsertInviteConfirmationTokens(ctx,
+		pgstore.InsertInviteConfirmationTokensParams{
+			TripID:         tripID,
+			ParticipantIds: participantIDs,
+			ExpiresAt:      pgtype.Timestamptz{Valid: true, Time: expiresAt},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("api: failed to insert confirmation tokens: %w", err)
+	}
+
+	links := make(map[uuid.UUID]inviteLink, len(tokens))
+	for _, t := range tokens {
+		token, err := api.signConfirmationToken(t.ID, tripID, t.ParticipantID, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		participantID := uuid.UUID(t.ParticipantID.Bytes)
+		path := api.baseURL + "/participants/" + participantID.String()
+		query := "?token=" + url.QueryEscape(token)
+		links[participantID] = inviteLink{path + "/confirm" + query, path + "/decline" + query}
+	}
+
+	return links, nil
+}
+
+// calendarLink returns the link a participant subscribes to in a calendar app
+// to follow the trip. Its token is the participant's stored calendar token,
+// so it never expires.
+func (api *API) calendarLink(tripID uuid.UUID, token uuid.UUID) string {
+	return api.baseURL + "/trips/" + tripID.String() + "/calendar.ics?token=" + token.String()
+}
+
+// unsubscribeLink returns the e-mail link a participant uses to stop e-mails
+// about changes to the trip. It needs no stored token, as using it twice does
+// no harm.
+func (api *API) unsubscribeLink(participantID uuid.UUID) (string, error) {
+	token, err := api.signer.Sign(auth.Claims{
+		ID:        uuid.New(),
+		Subject:   participantID.String(),
+		Purpose:   auth.PurposeUnsubscribe,
+		ExpiresAt: time.Now().Add(auth.UnsubscribeTokenTTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("api: failed to sign unsubscribe token: %w", err)
+	}
+
+	return api.baseURL + "/participants/" + participantID.String() + "/unsubscribe?token=" + url.QueryEscape(token), nil
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// versionETag is the strong ETag of a trip, activity or link at version.
+func versionETag(version int32) string {
+	return `"` + strconv.Itoa(int(version)) + `"`
+}
+
+// bodyETag is a weak ETag of the JSON representation of v, for responses
+// made of several rows that share no version.
+func bodyETag(v any) string {
+	// Response types always marshal, so the error is never set.
+	body, _ := json.Marshal(v)
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// notModified sets etag as the ETag of the response and reports whether the
+// If-None-Match header of r already names it, in which case it has written
+// a 304 and the handler must not write a body.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// ifMatch returns the problem to reply with unless the If-Match header of r
+// names version, the current version of the resource what is about to change.
+func ifMatch(r *http.Request, what string, version int32) *apiError {
+	return ifMatchETag(r, what, versionETag(version))
+}
+
+// ifMatchETag is ifMatch for resources without a version, such as lists,
+// whose current ETag is etag. Weak ETags match as they were sent, as these
+// resources have no other.
+func ifMatchETag(r *http.Request, what string, etag string) *apiError {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return &apiError{http.StatusPreconditionRequired, codePreconditionRequired,
+			"If-Match header is required, send the ETag of the " + what + " being changed"}
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return nil
+		}
+	}
+
+	return versionMismatch(what)
+}
+
+// versionMismatch is the problem to reply with when the resource what was
+// changed by someone else since the caller read it.
+func versionMismatch(what string) *apiError {
+	return &apiError{http.StatusPreconditionFailed, codeVersionMismatch,
+		"The " + what + " was changed since you last read it, reload it and try again"}
+}
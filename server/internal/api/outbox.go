@@ -0,0 +1,363 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"server/internal/email"
+	"server/internal/jobs"
+	"server/internal/pgstore"
+	"server/internal/tripdiff"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	// outboxPollInterval is how often the dispatcher looks for e-mails due.
+	outboxPollInterval = 5 * time.Second
+	outboxBatchSize    = 50
+
+	// outboxSendTimeout bounds sending an e-mail, or the invites to a trip,
+	// so a stuck mail server can't hold a worker. Job contexts are only
+	// cancelled on shutdown.
+	outboxSendTimeout = time.Minute
+
+	// An e-mail that keeps failing is retried after outboxBaseBackoff, then
+	// twice as long each time up to outboxMaxBackoff, and is dead after
+	// outboxMaxAttempts tries, some 15 hours after the first.
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = 6 * time.Hour
+	outboxMaxAttempts = 12
+)
+
+// errUndeliverable marks failures retrying can't fix, so the e-mail is dead
+// right away.
+var errUndeliverable = errors.New("api: e-mail can't be delivered")
+
+type loginLinkPayload struct {
+	TokenID   uuid.UUID `json:"token_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type changeEmailPayload struct {
+	TokenID   uuid.UUID `json:"token_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type tripUpdatedPayload struct {
+	Changes []tripdiff.Change `json:"changes"`
+}
+
+// emailParams builds the outbox row for an e-mail, for the pgstore
+// transactions to queue along with the change the e-mail is about.
+func emailParams(kind string, tripID uuid.UUID, participantID uuid.UUID, recipient string, payload any) (pgstore.EnqueueEmailParams, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return pgstore.EnqueueEmailParams{}, fmt.Errorf("api: failed to marshal %s e-mail payload: %w", kind, err)
+	}
+
+	return pgstore.EnqueueEmailParams{
+		Kind:          kind,
+		TripID:        pgtype.UUID{Valid: tripID != uuid.Nil, Bytes: tripID},
+		ParticipantID: pgtype.UUID{Valid: participantID != uuid.Nil, Bytes: participantID},
+		Recipient:     recipient,
+		Payload:       data,
+	}, nil
+}
+
+// DispatchEmails sends the e-mails due in the outbox on the pool until ctx is
+// done. It is safe to run on several instances at once, each e-mail is
+// claimed by one.
+func (api *API) DispatchEmails(ctx context.Context, pool *jobs.Pool) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		api.dispatchDueEmails(ctx, pool)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (api *API) dispatchDueEmails(ctx context.Context, pool *jobs.Pool) {
+	for ctx.Err() == nil {
+		emails, err := api.store.ClaimDueEmails(ctx, outboxBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				api.logger.Error("Failed to claim due e-mails", zap.Error(err))
+			}
+			return
+		}
+
+		// Invites to the same trip go out together, over one connection.
+		var batches [][]pgstore.EmailOutbox
+		invites := make(map[uuid.UUID]int)
+		for _, e := range emails {
+			if e.Kind != pgstore.EmailInvite {
+				batches = append(batches, []pgstore.EmailOutbox{e})
+				continue
+			}
+
+			tripID := uuid.UUID(e.TripID.Bytes)
+			i, ok := invites[tripID]
+			if !ok {
+				i = len(batches)
+				invites[tripID] = i
+				batches = append(batches, nil)
+			}
+			batches[i] = append(batches[i], e)
+		}
+
+		for _, batch := range batches {
+			job := func(ctx context.Context) error {
+				return api.deliverEmail(ctx, batch[0])
+			}
+			if batch[0].Kind == pgstore.EmailInvite {
+				job = func(ctx context.Context) error {
+					return api.deliverInvites(ctx, batch)
+				}
+			}
+
+			err := pool.Submit(ctx, "email:"+batch[0].Kind, job)
+			if err != nil {
+				// The e-mails left are claimed, so they wait out the claim
+				// before anyone tries them again.
+				if ctx.Err() == nil {
+					api.logger.Error("Failed to submit e-mail delivery", zap.Error(err))
+				}
+				return
+			}
+		}
+
+		if len(emails) < outboxBatchSize {
+			return
+		}
+	}
+}
+
+// deliverEmail sends a claimed e-mail and records the outcome, returning why
+// sending failed.
+func (api *API) deliverEmail(ctx context.Context, e pgstore.EmailOutbox) error {
+	sendCtx, cancel := context.WithTimeout(ctx, outboxSendTimeout)
+	defer cancel()
+
+	return api.recordDelivery(ctx, e, api.sendEmail(sendCtx, e))
+}
+
+// deliverInvites sends claimed invites to the same trip in one go and records
+// the outcome of each, returning why sending failed for those that did.
+func (api *API) deliverInvites(ctx context.Context, emails []pgstore.EmailOutbox) error {
+	sendCtx, cancel := context.WithTimeout(ctx, outboxSendTimeout)
+	defer cancel()
+
+	sendErrs := api.sendInvites(sendCtx, emails)
+
+	var errs []error
+	for i, e := range emails {
+		errs = append(errs, api.recordDelivery(ctx, e, sendErrs[i]))
+	}
+
+	return errors.Join(errs...)
+}
+
+// sendInvites builds the claimed invites to the same trip, loading what their
+// links need for all of them at once, and hands them to the mailer. It
+// returns an error for each invite, nil for those sent.
+func (api *API) sendInvites(ctx context.Context, emails []pgstore.EmailOutbox) []error {
+	tripID := uuid.UUID(emails[0].TripID.Bytes)
+	errs := make([]error, len(emails))
+	fail := func(err error) []error {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = err
+			}
+		}
+		return errs
+	}
+
+	ids := make([]uuid.UUID, len(emails))
+	for i, e := range emails {
+		ids[i] = uuid.UUID(e.ParticipantID.Bytes)
+	}
+
+	rows, err := api.store.GetParticipantCalendarTokens(ctx, ids)
+	if err != nil {
+		return fail(fmt.Errorf("api: failed to get participant calendar tokens: %w", err))
+	}
+	calendarTokens := make(map[uuid.UUID]uuid.UUID, len(rows))
+	for _, row := range rows {
+		calendarTokens[row.ID] = row.CalendarToken
+	}
+
+	// Participants removed since the invite was queued get no tokens.
+	var invited []uuid.UUID
+	for i, id := range ids {
+		if _, ok := calendarTokens[id]; !ok {
+			errs[i] = fmt.Errorf("%w: participant no longer exists", errUndeliverable)
+			continue
+		}
+		invited = append(invited, id)
+	}
+	if len(invited) == 0 {
+		return errs
+	}
+
+	links, err := api.inviteLinks(ctx, tripID, invited)
+	if err != nil {
+		return fail(err)
+	}
+
+	// sent[j] is the index in emails of invites[j].
+	var invites []email.Invite
+	var sent []int
+	for i, e := range emails {
+		if errs[i] != nil {
+			continue
+		}
+
+		link := links[ids[i]]
+		invites = append(invites, email.Invite{
+			Email:        e.Recipient,
+			ConfirmLink:  link.confirm,
+			DeclineLink:  link.decline,
+			CalendarLink: api.calendarLink(tripID, calendarTokens[ids[i]]),
+		})
+		sent = append(sent, i)
+	}
+
+	for j, err := range api.mailer.SendInvites(ctx, tripID, invites) {
+		errs[sent[j]] = err
+	}
+
+	return errs
+}
+
+// recordDelivery records the outcome of sending a claimed e-mail, returning
+// sendErr with what it means for the e-mail. Claiming it already counted the
+// attempt and moved it out of reach of other dispatchers for a while, so a
+// crash before this only delays it.
+func (api *API) recordDelivery(ctx context.Context, e pgstore.EmailOutbox, sendErr error) error {
+	if sendErr == nil {
+		err := api.store.MarkEmailSent(ctx, e.ID)
+		if err != nil {
+			api.logger.Error("Failed to mark e-mail sent", zap.Error(err), zap.String("email_id", e.ID.String()))
+		}
+		return nil
+	}
+
+	status := pgstore.EmailPending
+	nextAttemptAt := time.Now().Add(outboxBackoff(e.Attempts))
+	if e.Attempts >= outboxMaxAttempts || errors.Is(sendErr, errUndeliverable) {
+		status = pgstore.EmailDead
+		nextAttemptAt = time.Now()
+	}
+
+	err := api.store.MarkEmailFailed(ctx, pgstore.MarkEmailFailedParams{
+		Status:        status,
+		LastError:     pgtype.Text{Valid: true, String: sendErr.Error()},
+		NextAttemptAt: pgtype.Timestamptz{Valid: true, Time: nextAttemptAt},
+		ID:            e.ID,
+	})
+	if err != nil {
+		api.logger.Error("Failed to mark e-mail failed", zap.Error(err), zap.String("email_id", e.ID.String()))
+	}
+
+	return fmt.Errorf("api: e-mail %s is %s after %d attempts: %w", e.ID, status, e.Attempts, sendErr)
+}
+
+// outboxBackoff is how long to wait before trying an e-mail again after
+// attempts failed tries.
+func outboxBackoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		return outboxBaseBackoff
+	}
+
+	backoff := outboxBaseBackoff << (attempts - 1)
+	if backoff <= 0 || backoff > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+
+	return backoff
+}
+
+// sendEmail renders the e-mail from the outbox and hands it to the mailer.
+// Invites are sent by deliverInvites instead.
+func (api *API) sendEmail(ctx context.Context, e pgstore.EmailOutbox) error {
+	tripID := uuid.UUID(e.TripID.Bytes)
+	participantID := uuid.UUID(e.ParticipantID.Bytes)
+
+	switch e.Kind {
+	case pgstore.EmailConfirmTrip:
+		link, err := api.confirmationLink(ctx, tripID)
+		if err != nil {
+			return err
+		}
+
+		return api.mailer.SendConfirmTripEmailToTripOwner(ctx, tripID, link)
+
+	case pgstore.EmailTripCancelled:
+		return api.mailer.SendTripCancelledEmail(ctx, tripID, e.Recipient)
+
+	case pgstore.EmailTripUpdated:
+		var payload tripUpdatedPayload
+		err := json.Unmarshal(e.Payload, &payload)
+		if err != nil {
+			return fmt.Errorf("%w: invalid payload: %w", errUndeliverable, err)
+		}
+
+		link, err := api.unsubscribeLink(participantID)
+		if err != nil {
+			return err
+		}
+
+		return api.mailer.SendTripUpdatedEmail(ctx, tripID, e.Recipient, payload.Changes, link)
+
+	case pgstore.EmailLoginLink:
+		var payload loginLinkPayload
+		err := json.Unmarshal(e.Payload, &payload)
+		if err != nil {
+			return fmt.Errorf("%w: invalid payload: %w", errUndeliverable, err)
+		}
+
+		if time.Now().After(payload.ExpiresAt) {
+			return fmt.Errorf("%w: login link expired", errUndeliverable)
+		}
+
+		link, err := api.loginLink(payload.TokenID, e.Recipient, payload.ExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		return api.mailer.SendLoginLinkEmail(ctx, e.Recipient, link)
+
+	case pgstore.EmailChangeEmail:
+		var payload changeEmailPayload
+		err := json.Unmarshal(e.Payload, &payload)
+		if err != nil {
+			return fmt.Errorf("%w: invalid payload: %w", errUndeliverable, err)
+		}
+
+		if time.Now().After(payload.ExpiresAt) {
+			return fmt.Errorf("%w: e-mail change link expired", errUndeliverable)
+		}
+
+		link, err := api.emailChangeLink(payload.TokenID, payload.UserID, payload.ExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		return api.mailer.SendChangeEmailLink(ctx, e.Recipient, link)
+
+	default:
+		return fmt.Errorf("%w: unknown kind %q", errUndeliverable, e.Kind)
+	}
+}
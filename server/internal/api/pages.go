@@ -0,0 +1,50 @@
+package api
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"net/http"
+	"server/internal/api/spec"
+
+	"go.uber.org/zap"
+)
+
+//go:embed pages
+var pageFS embed.FS
+
+var actionPageTemplate = template.Must(template.ParseFS(pageFS, "pages/action.html.tmpl"))
+
+// actionPage is the page behind an e-mail link that changes something. Mail
+// scanners and link prefetchers open every link in a message, so following
+// the link only shows what it does, and the change is made by the POST to the
+// same URL the page sends when the recipient presses its button.
+type actionPage struct {
+	Title   string
+	Message string
+	Button  string
+	// Done replaces Message once the change is made.
+	Done   string
+	Action string
+}
+
+// writeActionPage writes page as the response to the GET of an e-mail link,
+// posting back to the URL of r.
+func (api *API) writeActionPage(w http.ResponseWriter, r *http.Request, page actionPage) *spec.Response {
+	page.Action = r.URL.RequestURI()
+
+	var body bytes.Buffer
+	err := actionPageTemplate.Execute(&body, page)
+	if err != nil {
+		api.logger.Error("Failed to render page", zap.Error(err), zap.String("path", r.URL.Path))
+		return api.problem(w, r, http.StatusInternalServerError, codeInternal, "Something went wrong, try again")
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	// The URL carries the token, keep it out of caches and referrers.
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+	return nil
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"server/internal/api/spec"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// Machine-readable codes of problem responses. Clients can rely on them, so
+// they must not change once released.
+const (
+	codeInvalidID            = "invalid_id"
+	codeInvalidJSON          = "invalid_json"
+	codeInvalidParameter     = "invalid_parameter"
+	codeValidationFailed     = "validation_failed"
+	codeUnauthorized         = "unauthorized"
+	codeForbidden            = "forbidden"
+	codeTripNotFound         = "trip_not_found"
+	codeParticipantNotFound  = "participant_not_found"
+	codeActivityNotFound     = "activity_not_found"
+	codeLinkNotFound         = "link_not_found"
+	codeUserNotFound         = "user_not_found"
+	codeInvalidToken         = "invalid_token"
+	codeTokenExpired         = "token_expired"
+	codeTokenUsed            = "token_used"
+	codeEmailTaken           = "email_taken"
+	codeTripStatusConflict   = "trip_status_conflict"
+	codeAlreadyAnswered      = "invite_already_answered"
+	codeActivitiesOutOfRange = "activities_out_of_range"
+	codeInviteThrottled      = "invite_throttled"
+	codePreconditionRequired = "precondition_required"
+	codeVersionMismatch      = "version_mismatch"
+	codeTemplateNotFound     = "template_not_found"
+	codeEmailFailed          = "email_failed"
+	codeInternal             = "internal_error"
+)
+
+const problemContentType = "application/problem+json"
+
+// apiError is a problem found by a helper shared by several handlers, which
+// the handler then writes with API.problem.
+type apiError struct {
+	status int
+	code   string
+	detail string
+}
+
+// problem writes an RFC 7807 problem response for r. It returns the nil
+// *spec.Response handlers hand back once they wrote the response themselves.
+func (api *API) problem(w http.ResponseWriter, r *http.Request, status int, code string, detail string) *spec.Response {
+	api.writeProblem(w, r, spec.Error{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Code:      code,
+		RequestID: middleware.GetReqID(r.Context()),
+	})
+	return nil
+}
+
+// invalidInput writes the 422 problem response for a request body that failed
+// validation, with one entry per invalid field.
+func (api *API) invalidInput(w http.ResponseWriter, r *http.Request, err error) *spec.Response {
+	p := spec.Error{
+		Type:      "about:blank",
+		Title:     http.StatusText(http.StatusUnprocessableEntity),
+		Status:    http.StatusUnprocessableEntity,
+		Detail:    "Invalid input",
+		Code:      codeValidationFailed,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		for _, fe := range verrs {
+			p.Errors = append(p.Errors, spec.FieldError{
+				Field:   fe.Field(),
+				Code:    fe.Tag(),
+				Message: fieldMessage(fe),
+			})
+		}
+	} else {
+		p.Detail = "Invalid input: " + err.Error()
+	}
+
+	api.writeProblem(w, r, p)
+	return nil
+}
+
+// fieldMessage describes the rule fe broke in words a client can show. Fields
+// are named as in the JSON body, as the validator reports them.
+func fieldMessage(fe validator.FieldError) string {
+	field := fe.Field()
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid e-mail address"
+	case "url":
+		return field + " must be a valid URL"
+	case "uuid":
+		return field + " must be a valid UUID"
+	case "timezone":
+		return field + " must be an IANA time zone, such as America/Sao_Paulo"
+	case "bcp47_language_tag":
+		return field + " must be a language tag, such as pt-BR"
+	case "min":
+		if fe.Kind() == reflect.Slice {
+			return field + " must have at least " + fe.Param() + " items"
+		}
+		return field + " must be at least " + fe.Param() + " characters long"
+	case "max":
+		if fe.Kind() == reflect.Slice {
+			return field + " must have at most " + fe.Param() + " items"
+		}
+		return field + " must be at most " + fe.Param() + " characters long"
+	case "gtefield":
+		return field + " must be on or after " + fe.Param()
+	case "not_past":
+		return field + " must not be before today in the trip's timezone"
+	case "max_duration":
+		return field + " must be at most " + strconv.Itoa(int(maxTripDuration/(24*time.Hour))) + " days after starts_at"
+	case "within_trip":
+		return field + " must be within the trip dates"
+	}
+
+	return field + " is invalid"
+}
+
+// ParamError writes the problem response for path and query parameters the
+// generated router could not parse. It is meant for spec.WithErrorHandler.
+func (api *API) ParamError(w http.ResponseWriter, r *http.Request, err error) {
+	api.problem(w, r, http.StatusBadRequest, codeInvalidParameter, err.Error())
+}
+
+func (api *API) writeProblem(w http.ResponseWriter, r *http.Request, p spec.Error) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		api.logger.Error("Failed to marshal problem", zap.Error(err))
+		w.WriteHeader(p.Status)
+		return
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(body)
+}
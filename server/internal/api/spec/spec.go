@@ -6,6 +6,7 @@ package spec
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
@@ -23,9 +24,95 @@ import (
 	"github.com/go-chi/render"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for EmailKind.
+var (
+	UnknownEmailKind = EmailKind{}
+
+	EmailKindChangeEmail = EmailKind{"change_email"}
+
+	EmailKindConfirmTrip = EmailKind{"confirm_trip"}
+
+	EmailKindInvite = EmailKind{"invite"}
+
+	EmailKindLoginLink = EmailKind{"login_link"}
+
+	EmailKindTripCancelled = EmailKind{"trip_cancelled"}
+
+	EmailKindTripUpdated = EmailKind{"trip_updated"}
+)
+
+// Defines values for EmailStatus.
+var (
+	UnknownEmailStatus = EmailStatus{}
+
+	EmailStatusDead = EmailStatus{"dead"}
+
+	EmailStatusPending = EmailStatus{"pending"}
+
+	EmailStatusSent = EmailStatus{"sent"}
+)
+
+// Defines values for InviteStatus.
+var (
+	UnknownInviteStatus = InviteStatus{}
+
+	InviteStatusAlreadyInvited = InviteStatus{"already_invited"}
+
+	InviteStatusCreated = InviteStatus{"created"}
+
+	InviteStatusInvalid = InviteStatus{"invalid"}
+)
+
+// Defines values for ParticipantRole.
+var (
+	UnknownParticipantRole = ParticipantRole{}
+
+	ParticipantRoleCoOrganizer = ParticipantRole{"co_organizer"}
+
+	ParticipantRoleParticipant = ParticipantRole{"participant"}
+
+	ParticipantRoleViewer = ParticipantRole{"viewer"}
+)
+
+// Defines values for RsvpStatus.
+var (
+	UnknownRsvpStatus = RsvpStatus{}
+
+	RsvpStatusConfirmed = RsvpStatus{"confirmed"}
+
+	RsvpStatusDeclined = RsvpStatus{"declined"}
+
+	RsvpStatusPending = RsvpStatus{"pending"}
+)
+
+// Defines values for TripStatus.
+var (
+	UnknownTripStatus = TripStatus{}
+
+	TripStatusArchived = TripStatus{"archived"}
+
+	TripStatusCancelled = TripStatus{"cancelled"}
+
+	TripStatusCompleted = TripStatus{"completed"}
+
+	TripStatusConfirmed = TripStatus{"confirmed"}
+
+	TripStatusDraft = TripStatus{"draft"}
+)
+
+// ChangeEmailRequest defines model for ChangeEmailRequest.
+type ChangeEmailRequest struct {
+	Email openapi_types.Email `json:"email" validate:"required,email"`
+}
+
 // CreateActivityRequest defines model for CreateActivityRequest.
 type CreateActivityRequest struct {
 	OccursAt time.Time `json:"occurs_at" validate:"required"`
+	Timezone *string   `json:"timezone" validate:"omitempty,timezone"`
 	Title    string    `json:"title" validate:"required"`
 }
 
@@ -53,6 +140,7 @@ type CreateTripRequest struct {
 	OwnerEmail     openapi_types.Email   `json:"owner_email" validate:"required,email"`
 	OwnerName      string                `json:"owner_name" validate:"required"`
 	StartsAt       time.Time             `json:"starts_at" validate:"required"`
+	Timezone       *string               `json:"timezone,omitempty" validate:"omitempty,timezone"`
 }
 
 // CreateTripResponse defines model for CreateTripResponse.
@@ -60,8 +148,50 @@ type CreateTripResponse struct {
 	TripID string `json:"tripId"`
 }
 
-// Bad request
+// EmailDelivery defines model for EmailDelivery.
+type EmailDelivery struct {
+	Attempts      int                 `json:"attempts"`
+	CreatedAt     time.Time           `json:"created_at"`
+	ID            string              `json:"id"`
+	Kind          EmailKind           `json:"kind"`
+	LastError     *string             `json:"last_error"`
+	NextAttemptAt *time.Time          `json:"next_attempt_at"`
+	Recipient     openapi_types.Email `json:"recipient"`
+	SentAt        *time.Time          `json:"sent_at"`
+	Status        EmailStatus         `json:"status"`
+}
+
+// EmailPreview defines model for EmailPreview.
+type EmailPreview struct {
+	HTML     string `json:"html"`
+	Locale   string `json:"locale"`
+	Subject  string `json:"subject"`
+	Template string `json:"template"`
+	Text     string `json:"text"`
+}
+
+// EmailTestSendRequest defines model for EmailTestSendRequest.
+type EmailTestSendRequest struct {
+	Email  openapi_types.Email `json:"email" validate:"required,email"`
+	Locale *string             `json:"locale" validate:"omitempty,bcp47_language_tag"`
+	TripID *string             `json:"trip_id" validate:"omitempty,uuid"`
+}
+
+// RFC 7807 problem details
 type Error struct {
+	Code      string       `json:"code"`
+	Detail    string       `json:"detail"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	RequestID string       `json:"request_id"`
+	Status    int          `json:"status"`
+	Title     string       `json:"title"`
+	Type      string       `json:"type"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Code    string `json:"code"`
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
@@ -72,9 +202,16 @@ type GetLinksResponse struct {
 
 // GetLinksResponseArray defines model for GetLinksResponseArray.
 type GetLinksResponseArray struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
-	URL   string `json:"url"`
+	ID       string `json:"id"`
+	Position int    `json:"position"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Version  int    `json:"version"`
+}
+
+// GetParticipantEmailsResponse defines model for GetParticipantEmailsResponse.
+type GetParticipantEmailsResponse struct {
+	Emails []EmailDelivery `json:"emails"`
 }
 
 // GetTripActivitiesResponse defines model for GetTripActivitiesResponse.
@@ -86,7 +223,10 @@ type GetTripActivitiesResponse struct {
 type GetTripActivitiesResponseInnerArray struct {
 	ID       string    `json:"id"`
 	OccursAt time.Time `json:"occurs_at"`
+	Past     bool      `json:"past"`
+	Timezone *string   `json:"timezone"`
 	Title    string    `json:"title"`
+	Version  int       `json:"version"`
 }
 
 // GetTripActivitiesResponseOuterArray defines model for GetTripActivitiesResponseOuterArray.
@@ -97,16 +237,22 @@ type GetTripActivitiesResponseOuterArray struct {
 
 // GetTripDetailsResponse defines model for GetTripDetailsResponse.
 type GetTripDetailsResponse struct {
-	Trip GetTripDetailsResponseTripObj `json:"trip"`
+	Activities   []GetTripActivitiesResponseOuterArray `json:"activities,omitempty"`
+	Links        []GetLinksResponseArray               `json:"links,omitempty"`
+	Participants []GetTripParticipantsResponseArray    `json:"participants,omitempty"`
+	Trip         GetTripDetailsResponseTripObj         `json:"trip"`
 }
 
 // GetTripDetailsResponseTripObj defines model for GetTripDetailsResponseTripObj.
 type GetTripDetailsResponseTripObj struct {
-	Destination string    `json:"destination"`
-	EndsAt      time.Time `json:"ends_at"`
-	ID          string    `json:"id"`
-	IsConfirmed bool      `json:"is_confirmed"`
-	StartsAt    time.Time `json:"starts_at"`
+	Destination string     `json:"destination"`
+	EndsAt      time.Time  `json:"ends_at"`
+	ID          string     `json:"id"`
+	IsConfirmed bool       `json:"is_confirmed"`
+	StartsAt    time.Time  `json:"starts_at"`
+	Status      TripStatus `json:"status"`
+	Timezone    string     `json:"timezone"`
+	Version     int        `json:"version"`
 }
 
 // GetTripParticipantsResponse defines model for GetTripParticipantsResponse.
@@ -116,39 +262,557 @@ type GetTripParticipantsResponse struct {
 
 // GetTripParticipantsResponseArray defines model for GetTripParticipantsResponseArray.
 type GetTripParticipantsResponseArray struct {
-	Email       openapi_types.Email `json:"email"`
-	ID          string              `json:"id"`
-	IsConfirmed bool                `json:"is_confirmed"`
-	Name        *string             `json:"name"`
+	Email         openapi_types.Email `json:"email"`
+	ID            string              `json:"id"`
+	IsConfirmed   bool                `json:"is_confirmed"`
+	Name          *string             `json:"name"`
+	NotifyChanges bool                `json:"notify_changes"`
+	Role          ParticipantRole     `json:"role"`
+	RsvpStatus    RsvpStatus          `json:"rsvp_status"`
 }
 
 // InviteParticipantRequest defines model for InviteParticipantRequest.
 type InviteParticipantRequest struct {
+	Emails []string `json:"emails" validate:"required,min=1,max=50"`
+}
+
+// InviteParticipantResponse defines model for InviteParticipantResponse.
+type InviteParticipantResponse struct {
+	Results []InviteParticipantResult `json:"results"`
+}
+
+// InviteParticipantResult defines model for InviteParticipantResult.
+type InviteParticipantResult struct {
+	Email         string       `json:"email"`
+	ParticipantID *string      `json:"participant_id"`
+	Status        InviteStatus `json:"status"`
+}
+
+// ListTripsResponse defines model for ListTripsResponse.
+type ListTripsResponse struct {
+	HasMore  bool                            `json:"has_more"`
+	Page     int                             `json:"page"`
+	PageSize int                             `json:"page_size"`
+	Trips    []GetTripDetailsResponseTripObj `json:"trips"`
+}
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
 	Email openapi_types.Email `json:"email" validate:"required,email"`
 }
 
+// LoginResponse defines model for LoginResponse.
+type LoginResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Token     string    `json:"token"`
+}
+
+// PatchActivityRequest defines model for PatchActivityRequest.
+type PatchActivityRequest struct {
+	OccursAt *time.Time `json:"occurs_at"`
+	Timezone *string    `json:"timezone" validate:"omitempty,timezone"`
+	Title    *string    `json:"title" validate:"omitempty,min=1"`
+}
+
+// PatchTripRequest defines model for PatchTripRequest.
+type PatchTripRequest struct {
+	Destination *string    `json:"destination,omitempty" validate:"omitempty,min=4"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	Timezone    *string    `json:"timezone,omitempty" validate:"omitempty,timezone"`
+}
+
+// ReorderLinksRequest defines model for ReorderLinksRequest.
+type ReorderLinksRequest struct {
+	LinkIds []string `json:"link_ids" validate:"required,dive,uuid"`
+}
+
+// UpdateActivityRequest defines model for UpdateActivityRequest.
+type UpdateActivityRequest struct {
+	OccursAt time.Time `json:"occurs_at" validate:"required"`
+	Timezone *string   `json:"timezone" validate:"omitempty,timezone"`
+	Title    string    `json:"title" validate:"required"`
+}
+
+// UpdateLinkRequest defines model for UpdateLinkRequest.
+type UpdateLinkRequest struct {
+	Title string `json:"title" validate:"required"`
+	URL   string `json:"url" validate:"required,url"`
+}
+
+// UpdateParticipantNotificationsRequest defines model for UpdateParticipantNotificationsRequest.
+type UpdateParticipantNotificationsRequest struct {
+	NotifyChanges bool `json:"notify_changes"`
+}
+
+// UpdateParticipantRoleRequest defines model for UpdateParticipantRoleRequest.
+type UpdateParticipantRoleRequest struct {
+	Role ParticipantRole `json:"role"`
+}
+
+// UpdateProfileRequest defines model for UpdateProfileRequest.
+type UpdateProfileRequest struct {
+	Locale   *string `json:"locale" validate:"omitempty,bcp47_language_tag"`
+	Name     *string `json:"name" validate:"omitempty,min=1"`
+	Timezone *string `json:"timezone" validate:"omitempty,timezone"`
+}
+
 // UpdateTripRequest defines model for UpdateTripRequest.
 type UpdateTripRequest struct {
 	Destination string    `json:"destination" validate:"required,min=4"`
 	EndsAt      time.Time `json:"ends_at" validate:"required"`
 	StartsAt    time.Time `json:"starts_at" validate:"required"`
+	Timezone    *string   `json:"timezone,omitempty" validate:"omitempty,timezone"`
+}
+
+// UpdateTripStatusRequest defines model for UpdateTripStatusRequest.
+type UpdateTripStatusRequest struct {
+	Status TripStatus `json:"status"`
+}
+
+// UserProfile defines model for UserProfile.
+type UserProfile struct {
+	Email    openapi_types.Email `json:"email"`
+	ID       string              `json:"id"`
+	Locale   *string             `json:"locale"`
+	Name     *string             `json:"name"`
+	Timezone *string             `json:"timezone"`
+}
+
+// EmailKind defines model for EmailKind.
+type EmailKind struct {
+	value string
+}
+
+func (t *EmailKind) ToValue() string {
+	return t.value
+}
+func (t EmailKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *EmailKind) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *EmailKind) FromValue(value string) error {
+	switch value {
+
+	case EmailKindChangeEmail.value:
+		t.value = value
+		return nil
+
+	case EmailKindConfirmTrip.value:
+		t.value = value
+		return nil
+
+	case EmailKindInvite.value:
+		t.value = value
+		return nil
+
+	case EmailKindLoginLink.value:
+		t.value = value
+		return nil
+
+	case EmailKindTripCancelled.value:
+		t.value = value
+		return nil
+
+	case EmailKindTripUpdated.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// EmailStatus defines model for EmailStatus.
+type EmailStatus struct {
+	value string
+}
+
+func (t *EmailStatus) ToValue() string {
+	return t.value
+}
+func (t EmailStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *EmailStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *EmailStatus) FromValue(value string) error {
+	switch value {
+
+	case EmailStatusDead.value:
+		t.value = value
+		return nil
+
+	case EmailStatusPending.value:
+		t.value = value
+		return nil
+
+	case EmailStatusSent.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// InviteStatus defines model for InviteStatus.
+type InviteStatus struct {
+	value string
+}
+
+func (t *InviteStatus) ToValue() string {
+	return t.value
+}
+func (t InviteStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *InviteStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *InviteStatus) FromValue(value string) error {
+	switch value {
+
+	case InviteStatusAlreadyInvited.value:
+		t.value = value
+		return nil
+
+	case InviteStatusCreated.value:
+		t.value = value
+		return nil
+
+	case InviteStatusInvalid.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// ParticipantRole defines model for ParticipantRole.
+type ParticipantRole struct {
+	value string
+}
+
+func (t *ParticipantRole) ToValue() string {
+	return t.value
+}
+func (t ParticipantRole) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *ParticipantRole) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *ParticipantRole) FromValue(value string) error {
+	switch value {
+
+	case ParticipantRoleCoOrganizer.value:
+		t.value = value
+		return nil
+
+	case ParticipantRoleParticipant.value:
+		t.value = value
+		return nil
+
+	case ParticipantRoleViewer.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// RsvpStatus defines model for RsvpStatus.
+type RsvpStatus struct {
+	value string
+}
+
+func (t *RsvpStatus) ToValue() string {
+	return t.value
+}
+func (t RsvpStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *RsvpStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *RsvpStatus) FromValue(value string) error {
+	switch value {
+
+	case RsvpStatusConfirmed.value:
+		t.value = value
+		return nil
+
+	case RsvpStatusDeclined.value:
+		t.value = value
+		return nil
+
+	case RsvpStatusPending.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// TripStatus defines model for TripStatus.
+type TripStatus struct {
+	value string
+}
+
+func (t *TripStatus) ToValue() string {
+	return t.value
+}
+func (t TripStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.value)
+}
+func (t *TripStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return t.FromValue(value)
+}
+func (t *TripStatus) FromValue(value string) error {
+	switch value {
+
+	case TripStatusArchived.value:
+		t.value = value
+		return nil
+
+	case TripStatusCancelled.value:
+		t.value = value
+		return nil
+
+	case TripStatusCompleted.value:
+		t.value = value
+		return nil
+
+	case TripStatusConfirmed.value:
+		t.value = value
+		return nil
+
+	case TripStatusDraft.value:
+		t.value = value
+		return nil
+
+	}
+	return fmt.Errorf("unknown enum value: %v", value)
+}
+
+// GetAdminEmailsTemplatePreviewParams defines parameters for GetAdminEmailsTemplatePreview.
+type GetAdminEmailsTemplatePreviewParams struct {
+	TripID *string `json:"trip_id,omitempty"`
+	Locale *string `json:"locale,omitempty"`
+}
+
+// PostAdminEmailsTemplateTestSendJSONBody defines parameters for PostAdminEmailsTemplateTestSend.
+type PostAdminEmailsTemplateTestSendJSONBody EmailTestSendRequest
+
+// PostAuthLoginJSONBody defines parameters for PostAuthLogin.
+type PostAuthLoginJSONBody LoginRequest
+
+// GetAuthLoginVerifyParams defines parameters for GetAuthLoginVerify.
+type GetAuthLoginVerifyParams struct {
+	Token string `json:"token"`
+}
+
+// PostAuthLoginVerifyParams defines parameters for PostAuthLoginVerify.
+type PostAuthLoginVerifyParams struct {
+	Token string `json:"token"`
+}
+
+// PutMeJSONBody defines parameters for PutMe.
+type PutMeJSONBody UpdateProfileRequest
+
+// PostMeEmailJSONBody defines parameters for PostMeEmail.
+type PostMeEmailJSONBody ChangeEmailRequest
+
+// GetMeEmailVerifyParams defines parameters for GetMeEmailVerify.
+type GetMeEmailVerifyParams struct {
+	Token string `json:"token"`
+}
+
+// PostMeEmailVerifyParams defines parameters for PostMeEmailVerify.
+type PostMeEmailVerifyParams struct {
+	Token string `json:"token"`
+}
+
+// GetMeTripsParams defines parameters for GetMeTrips.
+type GetMeTripsParams struct {
+	Filter   *GetMeTripsParamsFilter `json:"filter,omitempty"`
+	Sort     *GetMeTripsParamsSort   `json:"sort,omitempty"`
+	Page     *int                    `json:"page,omitempty"`
+	PageSize *int                    `json:"page_size,omitempty"`
+}
+
+// GetMeTripsParamsFilter defines parameters for GetMeTrips.
+type GetMeTripsParamsFilter string
+
+// GetMeTripsParamsSort defines parameters for GetMeTrips.
+type GetMeTripsParamsSort string
+
+// GetParticipantsParticipantIDConfirmParams defines parameters for GetParticipantsParticipantIDConfirm.
+type GetParticipantsParticipantIDConfirmParams struct {
+	Token string `json:"token"`
+}
+
+// PostParticipantsParticipantIDConfirmParams defines parameters for PostParticipantsParticipantIDConfirm.
+type PostParticipantsParticipantIDConfirmParams struct {
+	Token string `json:"token"`
+}
+
+// GetParticipantsParticipantIDDeclineParams defines parameters for GetParticipantsParticipantIDDecline.
+type GetParticipantsParticipantIDDeclineParams struct {
+	Token string `json:"token"`
+}
+
+// PostParticipantsParticipantIDDeclineParams defines parameters for PostParticipantsParticipantIDDecline.
+type PostParticipantsParticipantIDDeclineParams struct {
+	Token string `json:"token"`
+}
+
+// GetParticipantsParticipantIDUnsubscribeParams defines parameters for GetParticipantsParticipantIDUnsubscribe.
+type GetParticipantsParticipantIDUnsubscribeParams struct {
+	Token string `json:"token"`
+}
+
+// PostParticipantsParticipantIDUnsubscribeParams defines parameters for PostParticipantsParticipantIDUnsubscribe.
+type PostParticipantsParticipantIDUnsubscribeParams struct {
+	Token string `json:"token"`
 }
 
 // PostTripsJSONBody defines parameters for PostTrips.
 type PostTripsJSONBody CreateTripRequest
 
+// GetTripsTripIDParams defines parameters for GetTripsTripID.
+type GetTripsTripIDParams struct {
+	// Related data to embed in the response, comma separated.
+	Include []GetTripsTripIDParamsInclude `json:"include,omitempty"`
+}
+
+// GetTripsTripIDParamsInclude defines parameters for GetTripsTripID.
+type GetTripsTripIDParamsInclude string
+
+// PatchTripsTripIDParams defines parameters for PatchTripsTripID.
+type PatchTripsTripIDParams struct {
+	// What to do with activities that fall outside the new trip dates.
+	OutOfRange *PatchTripsTripIDParamsOutOfRange `json:"out_of_range,omitempty"`
+}
+
+// PatchTripsTripIDParamsOutOfRange defines parameters for PatchTripsTripID.
+type PatchTripsTripIDParamsOutOfRange string
+
 // PutTripsTripIDJSONBody defines parameters for PutTripsTripID.
 type PutTripsTripIDJSONBody UpdateTripRequest
 
+// PutTripsTripIDParams defines parameters for PutTripsTripID.
+type PutTripsTripIDParams struct {
+	// What to do with activities that fall outside the new trip dates.
+	OutOfRange *PutTripsTripIDParamsOutOfRange `json:"out_of_range,omitempty"`
+}
+
+// PutTripsTripIDParamsOutOfRange defines parameters for PutTripsTripID.
+type PutTripsTripIDParamsOutOfRange string
+
 // PostTripsTripIDActivitiesJSONBody defines parameters for PostTripsTripIDActivities.
 type PostTripsTripIDActivitiesJSONBody CreateActivityRequest
 
+// PatchTripsTripIDActivitiesActivityIDJSONBody defines parameters for PatchTripsTripIDActivitiesActivityID.
+type PatchTripsTripIDActivitiesActivityIDJSONBody PatchActivityRequest
+
+// PutTripsTripIDActivitiesActivityIDJSONBody defines parameters for PutTripsTripIDActivitiesActivityID.
+type PutTripsTripIDActivitiesActivityIDJSONBody UpdateActivityRequest
+
+// GetTripsTripIDCalendarIcsParams defines parameters for GetTripsTripIDCalendarIcs.
+type GetTripsTripIDCalendarIcsParams struct {
+	Token string `json:"token"`
+}
+
+// GetTripsTripIDConfirmParams defines parameters for GetTripsTripIDConfirm.
+type GetTripsTripIDConfirmParams struct {
+	Token string `json:"token"`
+}
+
+// PostTripsTripIDConfirmParams defines parameters for PostTripsTripIDConfirm.
+type PostTripsTripIDConfirmParams struct {
+	Token string `json:"token"`
+}
+
 // PostTripsTripIDInvitesJSONBody defines parameters for PostTripsTripIDInvites.
 type PostTripsTripIDInvitesJSONBody InviteParticipantRequest
 
 // PostTripsTripIDLinksJSONBody defines parameters for PostTripsTripIDLinks.
 type PostTripsTripIDLinksJSONBody CreateLinkRequest
 
+// PutTripsTripIDLinksOrderJSONBody defines parameters for PutTripsTripIDLinksOrder.
+type PutTripsTripIDLinksOrderJSONBody ReorderLinksRequest
+
+// PutTripsTripIDLinksLinkIDJSONBody defines parameters for PutTripsTripIDLinksLinkID.
+type PutTripsTripIDLinksLinkIDJSONBody UpdateLinkRequest
+
+// PutTripsTripIDParticipantsParticipantIDNotificationsJSONBody defines parameters for PutTripsTripIDParticipantsParticipantIDNotifications.
+type PutTripsTripIDParticipantsParticipantIDNotificationsJSONBody UpdateParticipantNotificationsRequest
+
+// PutTripsTripIDParticipantsParticipantIDRoleJSONBody defines parameters for PutTripsTripIDParticipantsParticipantIDRole.
+type PutTripsTripIDParticipantsParticipantIDRoleJSONBody UpdateParticipantRoleRequest
+
+// PutTripsTripIDStatusJSONBody defines parameters for PutTripsTripIDStatus.
+type PutTripsTripIDStatusJSONBody UpdateTripStatusRequest
+
+// PostAdminEmailsTemplateTestSendJSONRequestBody defines body for PostAdminEmailsTemplateTestSend for application/json ContentType.
+type PostAdminEmailsTemplateTestSendJSONRequestBody PostAdminEmailsTemplateTestSendJSONBody
+
+// Bind implements render.Binder.
+func (PostAdminEmailsTemplateTestSendJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
+type PostAuthLoginJSONRequestBody PostAuthLoginJSONBody
+
+// Bind implements render.Binder.
+func (PostAuthLoginJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutMeJSONRequestBody defines body for PutMe for application/json ContentType.
+type PutMeJSONRequestBody PutMeJSONBody
+
+// Bind implements render.Binder.
+func (PutMeJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PostMeEmailJSONRequestBody defines body for PostMeEmail for application/json ContentType.
+type PostMeEmailJSONRequestBody PostMeEmailJSONBody
+
+// Bind implements render.Binder.
+func (PostMeEmailJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // PostTripsJSONRequestBody defines body for PostTrips for application/json ContentType.
 type PostTripsJSONRequestBody PostTripsJSONBody
 
@@ -173,6 +837,22 @@ func (PostTripsTripIDActivitiesJSONRequestBody) Bind(*http.Request) error {
 	return nil
 }
 
+// PatchTripsTripIDActivitiesActivityIDJSONRequestBody defines body for PatchTripsTripIDActivitiesActivityID for application/json ContentType.
+type PatchTripsTripIDActivitiesActivityIDJSONRequestBody PatchTripsTripIDActivitiesActivityIDJSONBody
+
+// Bind implements render.Binder.
+func (PatchTripsTripIDActivitiesActivityIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutTripsTripIDActivitiesActivityIDJSONRequestBody defines body for PutTripsTripIDActivitiesActivityID for application/json ContentType.
+type PutTripsTripIDActivitiesActivityIDJSONRequestBody PutTripsTripIDActivitiesActivityIDJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDActivitiesActivityIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
 // PostTripsTripIDInvitesJSONRequestBody defines body for PostTripsTripIDInvites for application/json ContentType.
 type PostTripsTripIDInvitesJSONRequestBody PostTripsTripIDInvitesJSONBody
 
@@ -189,16 +869,56 @@ func (PostTripsTripIDLinksJSONRequestBody) Bind(*http.Request) error {
 	return nil
 }
 
-// Response is a common response struct for all the API calls.
-// A Response object may be instantiated via functions for specific operation responses.
-// It may also be instantiated directly, for the purpose of responding with a single status code.
-type Response struct {
-	body        interface{}
-	Code        int
-	contentType string
-}
+// PutTripsTripIDLinksOrderJSONRequestBody defines body for PutTripsTripIDLinksOrder for application/json ContentType.
+type PutTripsTripIDLinksOrderJSONRequestBody PutTripsTripIDLinksOrderJSONBody
 
-// Render implements the render.Renderer interface. It sets the Content-Type header
+// Bind implements render.Binder.
+func (PutTripsTripIDLinksOrderJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutTripsTripIDLinksLinkIDJSONRequestBody defines body for PutTripsTripIDLinksLinkID for application/json ContentType.
+type PutTripsTripIDLinksLinkIDJSONRequestBody PutTripsTripIDLinksLinkIDJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDLinksLinkIDJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutTripsTripIDParticipantsParticipantIDNotificationsJSONRequestBody defines body for PutTripsTripIDParticipantsParticipantIDNotifications for application/json ContentType.
+type PutTripsTripIDParticipantsParticipantIDNotificationsJSONRequestBody PutTripsTripIDParticipantsParticipantIDNotificationsJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDParticipantsParticipantIDNotificationsJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutTripsTripIDParticipantsParticipantIDRoleJSONRequestBody defines body for PutTripsTripIDParticipantsParticipantIDRole for application/json ContentType.
+type PutTripsTripIDParticipantsParticipantIDRoleJSONRequestBody PutTripsTripIDParticipantsParticipantIDRoleJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDParticipantsParticipantIDRoleJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// PutTripsTripIDStatusJSONRequestBody defines body for PutTripsTripIDStatus for application/json ContentType.
+type PutTripsTripIDStatusJSONRequestBody PutTripsTripIDStatusJSONBody
+
+// Bind implements render.Binder.
+func (PutTripsTripIDStatusJSONRequestBody) Bind(*http.Request) error {
+	return nil
+}
+
+// Response is a common response struct for all the API calls.
+// A Response object may be instantiated via functions for specific operation responses.
+// It may also be instantiated directly, for the purpose of responding with a single status code.
+type Response struct {
+	body        interface{}
+	Code        int
+	contentType string
+}
+
+// Render implements the render.Renderer interface. It sets the Content-Type header
 // and status code based on the response definition.
 func (resp *Response) Render(w http.ResponseWriter, r *http.Request) error {
 	w.Header().Set("Content-Type", resp.contentType)
@@ -230,9 +950,109 @@ func (resp *Response) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	return e.Encode(resp.body)
 }
 
-// PatchParticipantsParticipantIDConfirmJSON204Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
+// GetAdminEmailsTemplatePreviewJSON200Response is a constructor method for a GetAdminEmailsTemplatePreview response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetAdminEmailsTemplatePreviewJSON200Response(body EmailPreview) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostAdminEmailsTemplateTestSendJSON204Response is a constructor method for a PostAdminEmailsTemplateTestSend response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostAdminEmailsTemplateTestSendJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostAuthLoginJSON204Response is a constructor method for a PostAuthLogin response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostAuthLoginJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostAuthLoginVerifyJSON200Response is a constructor method for a PostAuthLoginVerify response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostAuthLoginVerifyJSON200Response(body LoginResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// GetMeJSON200Response is a constructor method for a GetMe response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetMeJSON200Response(body UserProfile) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutMeJSON204Response is a constructor method for a PutMe response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutMeJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostMeEmailJSON204Response is a constructor method for a PostMeEmail response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostMeEmailJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostMeEmailVerifyJSON204Response is a constructor method for a PostMeEmailVerify response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostMeEmailVerifyJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetMeTripsJSON200Response is a constructor method for a GetMeTrips response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetMeTripsJSON200Response(body ListTripsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PostParticipantsParticipantIDConfirmJSON204Response is a constructor method for a PostParticipantsParticipantIDConfirm response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostParticipantsParticipantIDConfirmJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostParticipantsParticipantIDDeclineJSON204Response is a constructor method for a PostParticipantsParticipantIDDecline response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PatchParticipantsParticipantIDConfirmJSON204Response(body interface{}) *Response {
+func PostParticipantsParticipantIDDeclineJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
 		Code:        204,
@@ -240,12 +1060,12 @@ func PatchParticipantsParticipantIDConfirmJSON204Response(body interface{}) *Res
 	}
 }
 
-// PatchParticipantsParticipantIDConfirmJSON400Response is a constructor method for a PatchParticipantsParticipantIDConfirm response.
+// PostParticipantsParticipantIDUnsubscribeJSON204Response is a constructor method for a PostParticipantsParticipantIDUnsubscribe response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PatchParticipantsParticipantIDConfirmJSON400Response(body Error) *Response {
+func PostParticipantsParticipantIDUnsubscribeJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
@@ -260,12 +1080,12 @@ func PostTripsJSON201Response(body CreateTripResponse) *Response {
 	}
 }
 
-// PostTripsJSON400Response is a constructor method for a PostTrips response.
+// DeleteTripsTripIDJSON204Response is a constructor method for a DeleteTripsTripID response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsJSON400Response(body Error) *Response {
+func DeleteTripsTripIDJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
@@ -280,12 +1100,12 @@ func GetTripsTripIDJSON200Response(body GetTripDetailsResponse) *Response {
 	}
 }
 
-// GetTripsTripIDJSON400Response is a constructor method for a GetTripsTripID response.
+// PatchTripsTripIDJSON204Response is a constructor method for a PatchTripsTripID response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDJSON400Response(body Error) *Response {
+func PatchTripsTripIDJSON204Response(body interface{}) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        204,
 		contentType: "application/json",
 	}
 }
@@ -300,19 +1120,79 @@ func PutTripsTripIDJSON204Response(body interface{}) *Response {
 	}
 }
 
-// PutTripsTripIDJSON400Response is a constructor method for a PutTripsTripID response.
+// GetTripsTripIDActivitiesJSON200Response is a constructor method for a GetTripsTripIDActivities response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PutTripsTripIDJSON400Response(body Error) *Response {
+func GetTripsTripIDActivitiesJSON200Response(body GetTripActivitiesResponse) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        200,
 		contentType: "application/json",
 	}
 }
 
-// GetTripsTripIDActivitiesJSON200Response is a constructor method for a GetTripsTripIDActivities response.
+// PostTripsTripIDActivitiesJSON201Response is a constructor method for a PostTripsTripIDActivities response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDActivitiesJSON200Response(body GetTripActivitiesResponse) *Response {
+func PostTripsTripIDActivitiesJSON201Response(body CreateActivityResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDActivitiesActivityIDJSON204Response is a constructor method for a DeleteTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDActivitiesActivityIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PatchTripsTripIDActivitiesActivityIDJSON204Response is a constructor method for a PatchTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PatchTripsTripIDActivitiesActivityIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDActivitiesActivityIDJSON204Response is a constructor method for a PutTripsTripIDActivitiesActivityID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDActivitiesActivityIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDCancelJSON204Response is a constructor method for a PostTripsTripIDCancel response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDCancelJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDConfirmJSON204Response is a constructor method for a PostTripsTripIDConfirm response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDConfirmJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDInvitesJSON200Response is a constructor method for a PostTripsTripIDInvites response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDInvitesJSON200Response(body InviteParticipantResponse) *Response {
 	return &Response{
 		body:        body,
 		Code:        200,
@@ -320,19 +1200,19 @@ func GetTripsTripIDActivitiesJSON200Response(body GetTripActivitiesResponse) *Re
 	}
 }
 
-// GetTripsTripIDActivitiesJSON400Response is a constructor method for a GetTripsTripIDActivities response.
+// GetTripsTripIDLinksJSON200Response is a constructor method for a GetTripsTripIDLinks response.
 // A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDActivitiesJSON400Response(body Error) *Response {
+func GetTripsTripIDLinksJSON200Response(body GetLinksResponse) *Response {
 	return &Response{
 		body:        body,
-		Code:        400,
+		Code:        200,
 		contentType: "application/json",
 	}
 }
 
-// PostTripsTripIDActivitiesJSON201Response is a constructor method for a PostTripsTripIDActivities response.
+// PostTripsTripIDLinksJSON201Response is a constructor method for a PostTripsTripIDLinks response.
 // A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDActivitiesJSON201Response(body CreateActivityResponse) *Response {
+func PostTripsTripIDLinksJSON201Response(body CreateLinkResponse) *Response {
 	return &Response{
 		body:        body,
 		Code:        201,
@@ -340,173 +1220,1332 @@ func PostTripsTripIDActivitiesJSON201Response(body CreateActivityResponse) *Resp
 	}
 }
 
-// PostTripsTripIDActivitiesJSON400Response is a constructor method for a PostTripsTripIDActivities response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDActivitiesJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
-	}
+// PutTripsTripIDLinksOrderJSON204Response is a constructor method for a PutTripsTripIDLinksOrder response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDLinksOrderJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDLinksLinkIDJSON204Response is a constructor method for a DeleteTripsTripIDLinksLinkID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDLinksLinkIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDLinksLinkIDJSON204Response is a constructor method for a PutTripsTripIDLinksLinkID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDLinksLinkIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDParticipantsJSON200Response is a constructor method for a GetTripsTripIDParticipants response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDParticipantsJSON200Response(body GetTripParticipantsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// DeleteTripsTripIDParticipantsParticipantIDJSON204Response is a constructor method for a DeleteTripsTripIDParticipantsParticipantID response.
+// A *Response is returned with the configured status code and content type from the spec.
+func DeleteTripsTripIDParticipantsParticipantIDJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// GetTripsTripIDParticipantsParticipantIDEmailsJSON200Response is a constructor method for a GetTripsTripIDParticipantsParticipantIDEmails response.
+// A *Response is returned with the configured status code and content type from the spec.
+func GetTripsTripIDParticipantsParticipantIDEmailsJSON200Response(body GetParticipantEmailsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDParticipantsParticipantIDNotificationsJSON204Response is a constructor method for a PutTripsTripIDParticipantsParticipantIDNotifications response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDParticipantsParticipantIDNotificationsJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PostTripsTripIDParticipantsParticipantIDResendJSON204Response is a constructor method for a PostTripsTripIDParticipantsParticipantIDResend response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PostTripsTripIDParticipantsParticipantIDResendJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDParticipantsParticipantIDRoleJSON204Response is a constructor method for a PutTripsTripIDParticipantsParticipantIDRole response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDParticipantsParticipantIDRoleJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// PutTripsTripIDStatusJSON204Response is a constructor method for a PutTripsTripIDStatus response.
+// A *Response is returned with the configured status code and content type from the spec.
+func PutTripsTripIDStatusJSON204Response(body interface{}) *Response {
+	return &Response{
+		body:        body,
+		Code:        204,
+		contentType: "application/json",
+	}
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Render an e-mail template without sending it.
+	// (GET /admin/emails/{template}/preview)
+	GetAdminEmailsTemplatePreview(w http.ResponseWriter, r *http.Request, template string, params GetAdminEmailsTemplatePreviewParams) *Response
+	// Send an e-mail template to an address.
+	// (POST /admin/emails/{template}/test-send)
+	PostAdminEmailsTemplateTestSend(w http.ResponseWriter, r *http.Request, template string) *Response
+	// Send a one-time login link to an e-mail.
+	// (POST /auth/login)
+	PostAuthLogin(w http.ResponseWriter, r *http.Request) *Response
+	// Show the page that exchanges a login link token for a session token.
+	// (GET /auth/login/verify)
+	GetAuthLoginVerify(w http.ResponseWriter, r *http.Request, params GetAuthLoginVerifyParams) *Response
+	// Exchange a login link token for a session token.
+	// (POST /auth/login/verify)
+	PostAuthLoginVerify(w http.ResponseWriter, r *http.Request, params PostAuthLoginVerifyParams) *Response
+	// Get the profile of the logged in user.
+	// (GET /me)
+	GetMe(w http.ResponseWriter, r *http.Request) *Response
+	// Update the profile of the logged in user.
+	// (PUT /me)
+	PutMe(w http.ResponseWriter, r *http.Request) *Response
+	// Send a link to change the e-mail of the logged in user.
+	// (POST /me/email)
+	PostMeEmail(w http.ResponseWriter, r *http.Request) *Response
+	// Show the page that changes the e-mail of a user.
+	// (GET /me/email/verify)
+	GetMeEmailVerify(w http.ResponseWriter, r *http.Request, params GetMeEmailVerifyParams) *Response
+	// Change the e-mail of a user to the address the link was sent to.
+	// (POST /me/email/verify)
+	PostMeEmailVerify(w http.ResponseWriter, r *http.Request, params PostMeEmailVerifyParams) *Response
+	// List the trips the logged in user owns or is invited to.
+	// (GET /me/trips)
+	GetMeTrips(w http.ResponseWriter, r *http.Request, params GetMeTripsParams) *Response
+	// Show the page that confirms a participant on a trip.
+	// (GET /participants/{participantId}/confirm)
+	GetParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string, params GetParticipantsParticipantIDConfirmParams) *Response
+	// Confirms a participant on a trip.
+	// (POST /participants/{participantId}/confirm)
+	PostParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string, params PostParticipantsParticipantIDConfirmParams) *Response
+	// Show the page that declines a participant invite to a trip.
+	// (GET /participants/{participantId}/decline)
+	GetParticipantsParticipantIDDecline(w http.ResponseWriter, r *http.Request, participantID string, params GetParticipantsParticipantIDDeclineParams) *Response
+	// Declines a participant invite to a trip.
+	// (POST /participants/{participantId}/decline)
+	PostParticipantsParticipantIDDecline(w http.ResponseWriter, r *http.Request, participantID string, params PostParticipantsParticipantIDDeclineParams) *Response
+	// Show the page that stops e-mails about changes to a trip for a participant.
+	// (GET /participants/{participantId}/unsubscribe)
+	GetParticipantsParticipantIDUnsubscribe(w http.ResponseWriter, r *http.Request, participantID string, params GetParticipantsParticipantIDUnsubscribeParams) *Response
+	// Stops e-mails about changes to a trip for a participant.
+	// (POST /participants/{participantId}/unsubscribe)
+	PostParticipantsParticipantIDUnsubscribe(w http.ResponseWriter, r *http.Request, participantID string, params PostParticipantsParticipantIDUnsubscribeParams) *Response
+	// Create a new trip
+	// (POST /trips)
+	PostTrips(w http.ResponseWriter, r *http.Request) *Response
+	// Delete a trip.
+	// (DELETE /trips/{tripId})
+	DeleteTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip details.
+	// (GET /trips/{tripId})
+	GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDParams) *Response
+	// Partially update a trip.
+	// (PATCH /trips/{tripId})
+	PatchTripsTripID(w http.ResponseWriter, r *http.Request, tripID string, params PatchTripsTripIDParams) *Response
+	// Update a trip.
+	// (PUT /trips/{tripId})
+	PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string, params PutTripsTripIDParams) *Response
+	// Get a trip activities.
+	// (GET /trips/{tripId}/activities)
+	GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Create a trip activity.
+	// (POST /trips/{tripId}/activities)
+	PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Delete a trip activity.
+	// (DELETE /trips/{tripId}/activities/{activityId})
+	DeleteTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string) *Response
+	// Partially update a trip activity.
+	// (PATCH /trips/{tripId}/activities/{activityId})
+	PatchTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string) *Response
+	// Update a trip activity.
+	// (PUT /trips/{tripId}/activities/{activityId})
+	PutTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request, tripID string, activityID string) *Response
+	// Get the trip and its activities as an iCalendar feed.
+	// (GET /trips/{tripId}/calendar.ics)
+	GetTripsTripIDCalendarIcs(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDCalendarIcsParams) *Response
+	// Cancel a trip and notify its participants.
+	// (POST /trips/{tripId}/cancel)
+	PostTripsTripIDCancel(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Show the page that confirms a trip.
+	// (GET /trips/{tripId}/confirm)
+	GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string, params GetTripsTripIDConfirmParams) *Response
+	// Confirm a trip and send e-mail invitations.
+	// (POST /trips/{tripId}/confirm)
+	PostTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string, params PostTripsTripIDConfirmParams) *Response
+	// Invite people to the trip.
+	// (POST /trips/{tripId}/invites)
+	PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Get a trip links.
+	// (GET /trips/{tripId}/links)
+	GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Create a trip link.
+	// (POST /trips/{tripId}/links)
+	PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Reorder a trip links.
+	// (PUT /trips/{tripId}/links/order)
+	PutTripsTripIDLinksOrder(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Delete a trip link.
+	// (DELETE /trips/{tripId}/links/{linkId})
+	DeleteTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request, tripID string, linkID string) *Response
+	// Update a trip link.
+	// (PUT /trips/{tripId}/links/{linkId})
+	PutTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request, tripID string, linkID string) *Response
+	// Get a trip participants.
+	// (GET /trips/{tripId}/participants)
+	GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string) *Response
+	// Remove a participant from a trip.
+	// (DELETE /trips/{tripId}/participants/{participantId})
+	DeleteTripsTripIDParticipantsParticipantID(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *Response
+	// Get the delivery status of the e-mails sent to a participant.
+	// (GET /trips/{tripId}/participants/{participantId}/emails)
+	GetTripsTripIDParticipantsParticipantIDEmails(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *Response
+	// Choose whether a participant gets e-mails about changes to the trip.
+	// (PUT /trips/{tripId}/participants/{participantId}/notifications)
+	PutTripsTripIDParticipantsParticipantIDNotifications(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *Response
+	// Resend the invite e-mail to a participant.
+	// (POST /trips/{tripId}/participants/{participantId}/resend)
+	PostTripsTripIDParticipantsParticipantIDResend(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *Response
+	// Change the role of a participant on a trip.
+	// (PUT /trips/{tripId}/participants/{participantId}/role)
+	PutTripsTripIDParticipantsParticipantIDRole(w http.ResponseWriter, r *http.Request, tripID string, participantID string) *Response
+	// Mark a trip as completed or archived.
+	// (PUT /trips/{tripId}/status)
+	PutTripsTripIDStatus(w http.ResponseWriter, r *http.Request, tripID string) *Response
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler          ServerInterface
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// GetAdminEmailsTemplatePreview operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminEmailsTemplatePreview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "template" -------------
+	var template string
+
+	if err := runtime.BindStyledParameter("simple", false, "template", chi.URLParam(r, "template"), &template); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "template"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminEmailsTemplatePreviewParams
+
+	// ------------- Optional query parameter "trip_id" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "trip_id", r.URL.Query(), &params.TripID); err != nil {
+		err = fmt.Errorf("invalid format for parameter trip_id: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "trip_id"})
+		return
+	}
+
+	// ------------- Optional query parameter "locale" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "locale", r.URL.Query(), &params.Locale); err != nil {
+		err = fmt.Errorf("invalid format for parameter locale: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "locale"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetAdminEmailsTemplatePreview(w, r, template, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAdminEmailsTemplateTestSend operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminEmailsTemplateTestSend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "template" -------------
+	var template string
+
+	if err := runtime.BindStyledParameter("simple", false, "template", chi.URLParam(r, "template"), &template); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "template"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostAdminEmailsTemplateTestSend(w, r, template)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostAuthLogin(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetAuthLoginVerify operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthLoginVerify(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuthLoginVerifyParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetAuthLoginVerify(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostAuthLoginVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLoginVerify(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAuthLoginVerifyParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostAuthLoginVerify(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetMe operation middleware
+func (siw *ServerInterfaceWrapper) GetMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetMe(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutMe operation middleware
+func (siw *ServerInterfaceWrapper) PutMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutMe(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostMeEmail operation middleware
+func (siw *ServerInterfaceWrapper) PostMeEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostMeEmail(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetMeEmailVerify operation middleware
+func (siw *ServerInterfaceWrapper) GetMeEmailVerify(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMeEmailVerifyParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetMeEmailVerify(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostMeEmailVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostMeEmailVerify(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostMeEmailVerifyParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostMeEmailVerify(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetMeTrips operation middleware
+func (siw *ServerInterfaceWrapper) GetMeTrips(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMeTripsParams
+
+	// ------------- Optional query parameter "filter" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "filter", r.URL.Query(), &params.Filter); err != nil {
+		err = fmt.Errorf("invalid format for parameter filter: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "filter"})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort); err != nil {
+		err = fmt.Errorf("invalid format for parameter sort: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "sort"})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page); err != nil {
+		err = fmt.Errorf("invalid format for parameter page: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "page"})
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize); err != nil {
+		err = fmt.Errorf("invalid format for parameter page_size: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "page_size"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetMeTrips(w, r, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetParticipantsParticipantIDConfirm operation middleware
+func (siw *ServerInterfaceWrapper) GetParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetParticipantsParticipantIDConfirmParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetParticipantsParticipantIDConfirm(w, r, participantID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostParticipantsParticipantIDConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostParticipantsParticipantIDConfirmParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostParticipantsParticipantIDConfirm(w, r, participantID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetParticipantsParticipantIDDecline operation middleware
+func (siw *ServerInterfaceWrapper) GetParticipantsParticipantIDDecline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetParticipantsParticipantIDDeclineParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetParticipantsParticipantIDDecline(w, r, participantID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostParticipantsParticipantIDDecline operation middleware
+func (siw *ServerInterfaceWrapper) PostParticipantsParticipantIDDecline(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostParticipantsParticipantIDDeclineParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostParticipantsParticipantIDDecline(w, r, participantID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetParticipantsParticipantIDUnsubscribe operation middleware
+func (siw *ServerInterfaceWrapper) GetParticipantsParticipantIDUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetParticipantsParticipantIDUnsubscribeParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetParticipantsParticipantIDUnsubscribe(w, r, participantID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostParticipantsParticipantIDUnsubscribe operation middleware
+func (siw *ServerInterfaceWrapper) PostParticipantsParticipantIDUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostParticipantsParticipantIDUnsubscribeParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostParticipantsParticipantIDUnsubscribe(w, r, participantID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTrips operation middleware
+func (siw *ServerInterfaceWrapper) PostTrips(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTrips(w, r)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteTripsTripID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTripsTripID(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripID operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDParams
+
+	// ------------- Optional query parameter "include" -------------
+
+	if err := runtime.BindQueryParameter("form", false, false, "include", r.URL.Query(), &params.Include); err != nil {
+		err = fmt.Errorf("invalid format for parameter include: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "include"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripID(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchTripsTripID operation middleware
+func (siw *ServerInterfaceWrapper) PatchTripsTripID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchTripsTripIDParams
+
+	// ------------- Optional query parameter "out_of_range" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "out_of_range", r.URL.Query(), &params.OutOfRange); err != nil {
+		err = fmt.Errorf("invalid format for parameter out_of_range: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "out_of_range"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchTripsTripID(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTripsTripID operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutTripsTripIDParams
+
+	// ------------- Optional query parameter "out_of_range" -------------
+
+	if err := runtime.BindQueryParameter("form", true, false, "out_of_range", r.URL.Query(), &params.OutOfRange); err != nil {
+		err = fmt.Errorf("invalid format for parameter out_of_range: %w", err)
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "out_of_range"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutTripsTripID(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDActivities operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDActivities(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTripsTripIDActivities operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDActivities(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DeleteTripsTripIDActivitiesActivityID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "activityId" -------------
+	var activityID string
+
+	if err := runtime.BindStyledParameter("simple", false, "activityId", chi.URLParam(r, "activityId"), &activityID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "activityId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.DeleteTripsTripIDActivitiesActivityID(w, r, tripID, activityID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PatchTripsTripIDActivitiesActivityID operation middleware
+func (siw *ServerInterfaceWrapper) PatchTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "activityId" -------------
+	var activityID string
+
+	if err := runtime.BindStyledParameter("simple", false, "activityId", chi.URLParam(r, "activityId"), &activityID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "activityId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PatchTripsTripIDActivitiesActivityID(w, r, tripID, activityID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PutTripsTripIDActivitiesActivityID operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDActivitiesActivityID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// ------------- Path parameter "activityId" -------------
+	var activityID string
+
+	if err := runtime.BindStyledParameter("simple", false, "activityId", chi.URLParam(r, "activityId"), &activityID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "activityId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PutTripsTripIDActivitiesActivityID(w, r, tripID, activityID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDCalendarIcs operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDCalendarIcs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDCalendarIcsParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDCalendarIcs(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTripsTripIDCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDCancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDCancel(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTripsTripIDConfirm operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTripsTripIDConfirmParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDConfirm(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
+}
+
+// PostTripsTripIDConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTripsTripIDConfirmParams
+
+	// ------------- Required query parameter "token" -------------
+
+	if err := runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token); err != nil {
+		err = fmt.Errorf("invalid format for parameter token: %w", err)
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{err, "token"})
+		return
+	}
+
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDConfirm(w, r, tripID, params)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
+
+	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDConfirmJSON204Response is a constructor method for a GetTripsTripIDConfirm response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDConfirmJSON204Response(body interface{}) *Response {
-	return &Response{
-		body:        body,
-		Code:        204,
-		contentType: "application/json",
-	}
-}
+// PostTripsTripIDInvites operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// GetTripsTripIDConfirmJSON400Response is a constructor method for a GetTripsTripIDConfirm response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDConfirmJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
-	}
-}
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
 
-// PostTripsTripIDInvitesJSON201Response is a constructor method for a PostTripsTripIDInvites response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDInvitesJSON201Response(body interface{}) *Response {
-	return &Response{
-		body:        body,
-		Code:        201,
-		contentType: "application/json",
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
 	}
-}
 
-// PostTripsTripIDInvitesJSON400Response is a constructor method for a PostTripsTripIDInvites response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDInvitesJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
-	}
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
-// GetTripsTripIDLinksJSON200Response is a constructor method for a GetTripsTripIDLinks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDLinksJSON200Response(body GetLinksResponse) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
-	}
-}
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.PostTripsTripIDInvites(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
 
-// GetTripsTripIDLinksJSON400Response is a constructor method for a GetTripsTripIDLinks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDLinksJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
-	}
+	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDLinksJSON201Response is a constructor method for a PostTripsTripIDLinks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDLinksJSON201Response(body CreateLinkResponse) *Response {
-	return &Response{
-		body:        body,
-		Code:        201,
-		contentType: "application/json",
-	}
-}
+// GetTripsTripIDLinks operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// PostTripsTripIDLinksJSON400Response is a constructor method for a PostTripsTripIDLinks response.
-// A *Response is returned with the configured status code and content type from the spec.
-func PostTripsTripIDLinksJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
-	}
-}
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
 
-// GetTripsTripIDParticipantsJSON200Response is a constructor method for a GetTripsTripIDParticipants response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDParticipantsJSON200Response(body GetTripParticipantsResponse) *Response {
-	return &Response{
-		body:        body,
-		Code:        200,
-		contentType: "application/json",
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
 	}
-}
 
-// GetTripsTripIDParticipantsJSON400Response is a constructor method for a GetTripsTripIDParticipants response.
-// A *Response is returned with the configured status code and content type from the spec.
-func GetTripsTripIDParticipantsJSON400Response(body Error) *Response {
-	return &Response{
-		body:        body,
-		Code:        400,
-		contentType: "application/json",
-	}
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Confirms a participant on a trip.
-	// (PATCH /participants/{participantId}/confirm)
-	PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request, participantID string) *Response
-	// Create a new trip
-	// (POST /trips)
-	PostTrips(w http.ResponseWriter, r *http.Request) *Response
-	// Get a trip details.
-	// (GET /trips/{tripId})
-	GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Update a trip.
-	// (PUT /trips/{tripId})
-	PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Get a trip activities.
-	// (GET /trips/{tripId}/activities)
-	GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Create a trip activity.
-	// (POST /trips/{tripId}/activities)
-	PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Confirm a trip and send e-mail invitations.
-	// (GET /trips/{tripId}/confirm)
-	GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Invite someone to the trip.
-	// (POST /trips/{tripId}/invites)
-	PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Get a trip links.
-	// (GET /trips/{tripId}/links)
-	GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Create a trip link.
-	// (POST /trips/{tripId}/links)
-	PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripID string) *Response
-	// Get a trip participants.
-	// (GET /trips/{tripId}/participants)
-	GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request, tripID string) *Response
-}
+	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := siw.Handler.GetTripsTripIDLinks(w, r, tripID)
+		if resp != nil {
+			if resp.body != nil {
+				render.Render(w, r, resp)
+			} else {
+				w.WriteHeader(resp.Code)
+			}
+		}
+	})
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler          ServerInterface
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	handler(w, r.WithContext(ctx))
 }
 
-// PatchParticipantsParticipantIDConfirm operation middleware
-func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDLinks operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// ------------- Path parameter "participantId" -------------
-	var participantID string
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
 
-	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PatchParticipantsParticipantIDConfirm(w, r, participantID)
+		resp := siw.Handler.PostTripsTripIDLinks(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -519,12 +2558,22 @@ func (siw *ServerInterfaceWrapper) PatchParticipantsParticipantIDConfirm(w http.
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTrips operation middleware
-func (siw *ServerInterfaceWrapper) PostTrips(w http.ResponseWriter, r *http.Request) {
+// PutTripsTripIDLinksOrder operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDLinksOrder(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// ------------- Path parameter "tripId" -------------
+	var tripID string
+
+	if err := runtime.BindStyledParameter("simple", false, "tripId", chi.URLParam(r, "tripId"), &tripID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "tripId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTrips(w, r)
+		resp := siw.Handler.PutTripsTripIDLinksOrder(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -537,8 +2586,8 @@ func (siw *ServerInterfaceWrapper) PostTrips(w http.ResponseWriter, r *http.Requ
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripID operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http.Request) {
+// DeleteTripsTripIDLinksLinkID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -549,8 +2598,18 @@ func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Path parameter "linkId" -------------
+	var linkID string
+
+	if err := runtime.BindStyledParameter("simple", false, "linkId", chi.URLParam(r, "linkId"), &linkID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "linkId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripID(w, r, tripID)
+		resp := siw.Handler.DeleteTripsTripIDLinksLinkID(w, r, tripID, linkID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -563,8 +2622,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripID(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
-// PutTripsTripID operation middleware
-func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http.Request) {
+// PutTripsTripIDLinksLinkID operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDLinksLinkID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -575,8 +2634,18 @@ func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Path parameter "linkId" -------------
+	var linkID string
+
+	if err := runtime.BindStyledParameter("simple", false, "linkId", chi.URLParam(r, "linkId"), &linkID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "linkId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PutTripsTripID(w, r, tripID)
+		resp := siw.Handler.PutTripsTripIDLinksLinkID(w, r, tripID, linkID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -589,8 +2658,8 @@ func (siw *ServerInterfaceWrapper) PutTripsTripID(w http.ResponseWriter, r *http
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDActivities operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+// GetTripsTripIDParticipants operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -601,8 +2670,10 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWrite
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDActivities(w, r, tripID)
+		resp := siw.Handler.GetTripsTripIDParticipants(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -615,8 +2686,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDActivities(w http.ResponseWrite
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDActivities operation middleware
-func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request) {
+// DeleteTripsTripIDParticipantsParticipantID operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTripsTripIDParticipantsParticipantID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -627,8 +2698,18 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWrit
 		return
 	}
 
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTripsTripIDActivities(w, r, tripID)
+		resp := siw.Handler.DeleteTripsTripIDParticipantsParticipantID(w, r, tripID, participantID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -641,8 +2722,8 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDActivities(w http.ResponseWrit
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDConfirm operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request) {
+// GetTripsTripIDParticipantsParticipantIDEmails operation middleware
+func (siw *ServerInterfaceWrapper) GetTripsTripIDParticipantsParticipantIDEmails(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -653,8 +2734,18 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter,
 		return
 	}
 
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDConfirm(w, r, tripID)
+		resp := siw.Handler.GetTripsTripIDParticipantsParticipantIDEmails(w, r, tripID, participantID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -667,8 +2758,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDConfirm(w http.ResponseWriter,
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDInvites operation middleware
-func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request) {
+// PutTripsTripIDParticipantsParticipantIDNotifications operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDParticipantsParticipantIDNotifications(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -679,8 +2770,18 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter,
 		return
 	}
 
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTripsTripIDInvites(w, r, tripID)
+		resp := siw.Handler.PutTripsTripIDParticipantsParticipantIDNotifications(w, r, tripID, participantID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -693,8 +2794,8 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDInvites(w http.ResponseWriter,
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDLinks operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request) {
+// PostTripsTripIDParticipantsParticipantIDResend operation middleware
+func (siw *ServerInterfaceWrapper) PostTripsTripIDParticipantsParticipantIDResend(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -705,8 +2806,18 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDLinks(w http.ResponseWriter, r
 		return
 	}
 
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDLinks(w, r, tripID)
+		resp := siw.Handler.PostTripsTripIDParticipantsParticipantIDResend(w, r, tripID, participantID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -719,8 +2830,8 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDLinks(w http.ResponseWriter, r
 	handler(w, r.WithContext(ctx))
 }
 
-// PostTripsTripIDLinks operation middleware
-func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request) {
+// PutTripsTripIDParticipantsParticipantIDRole operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDParticipantsParticipantIDRole(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -731,8 +2842,18 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r
 		return
 	}
 
+	// ------------- Path parameter "participantId" -------------
+	var participantID string
+
+	if err := runtime.BindStyledParameter("simple", false, "participantId", chi.URLParam(r, "participantId"), &participantID); err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{err, "participantId"})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.PostTripsTripIDLinks(w, r, tripID)
+		resp := siw.Handler.PutTripsTripIDParticipantsParticipantIDRole(w, r, tripID, participantID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -745,8 +2866,8 @@ func (siw *ServerInterfaceWrapper) PostTripsTripIDLinks(w http.ResponseWriter, r
 	handler(w, r.WithContext(ctx))
 }
 
-// GetTripsTripIDParticipants operation middleware
-func (siw *ServerInterfaceWrapper) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request) {
+// PutTripsTripIDStatus operation middleware
+func (siw *ServerInterfaceWrapper) PutTripsTripIDStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	// ------------- Path parameter "tripId" -------------
@@ -757,8 +2878,10 @@ func (siw *ServerInterfaceWrapper) GetTripsTripIDParticipants(w http.ResponseWri
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp := siw.Handler.GetTripsTripIDParticipants(w, r, tripID)
+		resp := siw.Handler.PutTripsTripIDStatus(w, r, tripID)
 		if resp != nil {
 			if resp.body != nil {
 				render.Render(w, r, resp)
@@ -886,17 +3009,50 @@ func Handler(si ServerInterface, opts ...ServerOption) http.Handler {
 	}
 
 	r.Route(options.BaseURL, func(r chi.Router) {
-		r.Patch("/participants/{participantId}/confirm", wrapper.PatchParticipantsParticipantIDConfirm)
+		r.Get("/admin/emails/{template}/preview", wrapper.GetAdminEmailsTemplatePreview)
+		r.Post("/admin/emails/{template}/test-send", wrapper.PostAdminEmailsTemplateTestSend)
+		r.Post("/auth/login", wrapper.PostAuthLogin)
+		r.Get("/auth/login/verify", wrapper.GetAuthLoginVerify)
+		r.Post("/auth/login/verify", wrapper.PostAuthLoginVerify)
+		r.Get("/me", wrapper.GetMe)
+		r.Put("/me", wrapper.PutMe)
+		r.Post("/me/email", wrapper.PostMeEmail)
+		r.Get("/me/email/verify", wrapper.GetMeEmailVerify)
+		r.Post("/me/email/verify", wrapper.PostMeEmailVerify)
+		r.Get("/me/trips", wrapper.GetMeTrips)
+		r.Get("/participants/{participantId}/confirm", wrapper.GetParticipantsParticipantIDConfirm)
+		r.Post("/participants/{participantId}/confirm", wrapper.PostParticipantsParticipantIDConfirm)
+		r.Get("/participants/{participantId}/decline", wrapper.GetParticipantsParticipantIDDecline)
+		r.Post("/participants/{participantId}/decline", wrapper.PostParticipantsParticipantIDDecline)
+		r.Get("/participants/{participantId}/unsubscribe", wrapper.GetParticipantsParticipantIDUnsubscribe)
+		r.Post("/participants/{participantId}/unsubscribe", wrapper.PostParticipantsParticipantIDUnsubscribe)
 		r.Post("/trips", wrapper.PostTrips)
+		r.Delete("/trips/{tripId}", wrapper.DeleteTripsTripID)
 		r.Get("/trips/{tripId}", wrapper.GetTripsTripID)
+		r.Patch("/trips/{tripId}", wrapper.PatchTripsTripID)
 		r.Put("/trips/{tripId}", wrapper.PutTripsTripID)
 		r.Get("/trips/{tripId}/activities", wrapper.GetTripsTripIDActivities)
 		r.Post("/trips/{tripId}/activities", wrapper.PostTripsTripIDActivities)
+		r.Delete("/trips/{tripId}/activities/{activityId}", wrapper.DeleteTripsTripIDActivitiesActivityID)
+		r.Patch("/trips/{tripId}/activities/{activityId}", wrapper.PatchTripsTripIDActivitiesActivityID)
+		r.Put("/trips/{tripId}/activities/{activityId}", wrapper.PutTripsTripIDActivitiesActivityID)
+		r.Get("/trips/{tripId}/calendar.ics", wrapper.GetTripsTripIDCalendarIcs)
+		r.Post("/trips/{tripId}/cancel", wrapper.PostTripsTripIDCancel)
 		r.Get("/trips/{tripId}/confirm", wrapper.GetTripsTripIDConfirm)
+		r.Post("/trips/{tripId}/confirm", wrapper.PostTripsTripIDConfirm)
 		r.Post("/trips/{tripId}/invites", wrapper.PostTripsTripIDInvites)
 		r.Get("/trips/{tripId}/links", wrapper.GetTripsTripIDLinks)
 		r.Post("/trips/{tripId}/links", wrapper.PostTripsTripIDLinks)
+		r.Put("/trips/{tripId}/links/order", wrapper.PutTripsTripIDLinksOrder)
+		r.Delete("/trips/{tripId}/links/{linkId}", wrapper.DeleteTripsTripIDLinksLinkID)
+		r.Put("/trips/{tripId}/links/{linkId}", wrapper.PutTripsTripIDLinksLinkID)
 		r.Get("/trips/{tripId}/participants", wrapper.GetTripsTripIDParticipants)
+		r.Delete("/trips/{tripId}/participants/{participantId}", wrapper.DeleteTripsTripIDParticipantsParticipantID)
+		r.Get("/trips/{tripId}/participants/{participantId}/emails", wrapper.GetTripsTripIDParticipantsParticipantIDEmails)
+		r.Put("/trips/{tripId}/participants/{participantId}/notifications", wrapper.PutTripsTripIDParticipantsParticipantIDNotifications)
+		r.Post("/trips/{tripId}/participants/{participantId}/resend", wrapper.PostTripsTripIDParticipantsParticipantIDResend)
+		r.Put("/trips/{tripId}/participants/{participantId}/role", wrapper.PutTripsTripIDParticipantsParticipantIDRole)
+		r.Put("/trips/{tripId}/status", wrapper.PutTripsTripIDStatus)
 	})
 	return r
 }
@@ -922,30 +3078,90 @@ func WithErrorHandler(handler func(w http.ResponseWriter, r *http.Request, err e
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+Raz27bOBN/FYLfd5TjdDcnAXtom6LwItgGRRd7KIqAlsY2E4lUyZFTw/DT7GFPe9wn",
-	"yIstSMo2Jcs2rcSbOr20tiJy/vxmfjNDek4TmRdSgEBN4znVyQRyZj++VcAQXifIpxxnH+FrCRrNH1ia",
-	"cuRSsOxayQIUctA0HrFMQ0QL79GcyiQplb5hdt1Iqtx8oilD6CHPgUYUZwXQmGpUXIxpRL/1xrIH31Cx",
-	"HrKx3WTKMm6W0Jgq+FpyBSldLCKKHDMwL3TeYxGtv8WfPW2Xm39ZKSiHt5AgXUQbftGFFBoOdAyrlg/S",
-	"mmfKkqcbTmmq6a3drt8VF3fdMHu8WyNaqqxul+KdsY7MZhtYOS2dpH1e6IRQxsVdF3Sqddt1+qR40Q2Z",
-	"FDRywczb5mvOxRWIMU5ofNHZuTkXv1xYIyBnPNM3KG+4mHK0/uIIua75wL616YTVA6YUm4WLT/kUIren",
-	"1UGkx2ILeS9A3ThR+w0KNmCtuxMgWP7Y5NHIFB7HDY1Y9QPKl7sGoiUsapbW/bov6DslIipedEnEal2b",
-	"Tu+UkmqvGinoRPHCpRt9w1KiqrRtqpiD1mzcgntTp+WLbUq9BzR0pR/BV7qWs/9XMKIx/V9/XeL7VX3v",
-	"N4W9tmnbTOM2btNByrv9DrOAh4C8tewHVp2mSU7GnmLyHtAEcFXzOejHVf2lvYFAtYv+UCKoMNg8sQdZ",
-	"NxBiKeIoSB7aHe4AfxeqazEHWe85+PlQ9iDYQDmijuDDfNekfmapPCw0LgFNEXgEgQc6oCHIPPowvG2l",
-	"9gP0XW5ztG7r4M5lEYXmCNc3iRQjrnJIvbgfSpkBE7RDu9CaKyGdQE2VHd6/Zgp5wgsmsGvIFN4WhyZR",
-	"m/gwnqxJPdDALkQR2oyuoqVDdCz7UVFmGRsa7kRVQlBMVA3eUqe98A9sf+g5p9uUc7QWvWHj9pb19yL9",
-	"nue0481I39PksQmM2YOLkaxc7PXm73QBCR/xhD389fAPaJIy8vp6QAqmGJFkyJK7HojUPGZF5l77U5Ii",
-	"Y0KcgSKJFBpV+fB3ykhaKiYQiCS/Xf1BfpWlEjAzKz/K5A5QA8OzVXMR0+UeNKJTUNrp8+rs/OzcdjgF",
-	"CFZwGtOf7aOIFgwn1k19n236c+/bIF30q0xzXIjJxB6mFaCsx8w0RK/NY5+JvM+Dy7fVeiNQsRwQlKbx",
-	"5znlRj+jxDLBY1oTTX2cHFU4fg0ZwL6YxY4PrY0/nV+Y/xIpEITLosL631jRv9UuP9b7gyhzEx2GrEwA",
-	"1EnLBkAd+EsYsTJDsiozi4henJ8fJHRXSXGDYotgfxq0mVPmOVMzGtPK85ow4jmWSEEYMX2LDR6bKs2C",
-	"Y/bpm1dcCZSOdxqoS21LkK5wAo1vZDp7MoM3j6gaqWuB2ID51VEUWGJ6GrhbxQkjAu4t0B7ODlQP4P7c",
-	"nU4sjCJjaAG6ajW0+WdwGZTH1YHH0ybw0/l0yyxxGui+B6zyl6TOgLMWfCNalG1JWz4blk/PEJvNURBD",
-	"/HiFwDmqhfW3s0G/fnRQEUNd4KcJ10TJEoHc8ywjCrBUgrAsIzgBYmRqMgS8BxD2iQ3aVYdFmEhJ1WO5",
-	"lyMCU/uq1GZLnMgSyVoRo/kualqfWbwgkmo56Ts5nqpDuAw+/8DH8NXuLuNZIT5Wd9O80n6WDmfj/vjE",
-	"uhw/xGZbA6yF4rzJJqDxOWSOOQq1/LADzApjkRJthmfo5YxnxN4CWlV0YFFz94YhQ43DfFC9f9pcs/U0",
-	"7Ah08xLCzvmLaJmDFEBQrpqXkIl5HW2re9AAdrFXli+kbanfHZ9ct2Jh85Gu7ppDe5T/HspjtSf+L7ee",
-	"pTWp/WjqFNsSEzptodTCFs2LpgDS8M9cX9DI03prd3I04uO5q24sFv8GAAD//6I5Fyf6KgAA",
+	"H4sIAAAAAAAC/+xdX5PcNnL/KigmVUkq1I5ky/Hdpu5BJ8muvZMs1UrOPbhUU1iyZwZeEqABcHfHqv00",
+	"echTHvMJ/MVSDYAccIacIbkz+xcvtpZDAg2g+9d/0A18jRKRF4ID1yo6/hqpZAE5Nf98vaB8Dm9zyrJT",
+	"+K0EpfEpTVOmmeA0+yhFAVIzUNHxjGYK4qjwHn2NAD/Ff8yEzKmOjt2TONLLAqLjSGnJ+DyKo6tnc/EM",
+	"rrSkzzSdm48vaMZSqvE1Cb+VTEIa28+vr6/j+ll0/Itr9UvdrDj7FRIdXcfRawlUw6tEswuml+NGIZKk",
+	"lGpKdWMkSNozzXIYPZoIh4Et/C444Du8zDJ6lkF0rGUJQ5sVOdOQF3oZ123aDnRmWh9N5Npkr6ajarzP",
+	"xKtCcAUDZ566z0/SxtSXJUs3Zn2dTO/bbvreMX4+jiluPq1xVMqmcJSSjRcNbGxjrSyVtqddszBqhTLG",
+	"z8esjvuum6bPkhXjViYFpRmn+Db+mTP+DvhcL6Ljl6MnN2f8Ly/NIAzUqKkWU8YvmDbzhXKnesDcdf2A",
+	"SkmX/btP2QXU2BdHwNNDwZG45CCnB8PtqgNO85sKj9JU6ttA5T2g8Brz+xzqD2S1si181pi65kLtkqJR",
+	"kq0lK8ZItvuujSZjSbyBjF2AXA5VBdpMq/JWhHENc5DYcmJGm/bnhus4Yn0GF0fnjJsX/1nCLDqO/mmy",
+	"MpcmzlaamIH9HV+8jqOMKj0FKYXcrdOv44jDlZ660W2jf2dDEhJWMOC6HxIp4DfrT2mqS9Vraj7ZV9dZ",
+	"xUy4mV+f+rrleLXojVndnLMGA6yG1smDf3drCrzMkZDEGLrTaq4SwWdM5lNk5SiOagHMxJzxKaounFDJ",
+	"imlCeQJZBmn1oCxwDn32X02Y6fqjhAsGlwO5f6HzbBOLkNlEQtvsEFyf0g667TecuYxq6Pjxqu2rdTmv",
+	"mqiJWHXp2ogt3Z3L8KnmoGohCuCpRVllWSEFumUyP4PSn4Cn98w78ZdlXzb9WVK8/H6aUT4v6RymmtoV",
+	"MUzXjmT76tk0N8DleltB39alSEElkhXWSotOf3hNvv/T8+9JIcVZBjlJQaP+i9bXLBFpO9PaD1p/Mqih",
+	"GobaNsT6gUGW2kGsG2xuDkBpN+dbQHFTSXW4DHUnOyUOf41rm75GSTf02E5Og8S29fHGN0xeOud+hi22",
+	"/pKDUnTeY2y2iXoI1Xdt9P8IGh0WdQOPpT8zrHf2quKDJl+0eDeqF/G2vWEj6Gm4FEKxygkawox9vNLr",
+	"OLoAqTpab1Pzvifq0bZqp2O2PlKpWcIKyrWB/LHLbu3p3uveNFV3rbdrvGMIaIS7QAgDdbNQCIP+Y+js",
+	"+kOpQfbjZK/bQaM74bzq4iDMPTQmh/JAlW/WnAmRAeXRoBjcVsEZKRHNeJqjxJG7Uzx2LvDdcZnHAi16",
+	"NHXWZ5+1W3efrdXZjzXfWEPinktdfBi1hFxUw+fgIXjQu7sj4yv1a3dtSfDRh7NfWwMJA1a2auZgwcLB",
+	"gbf+MQamps7nhLQdooZGu3r75zhtlXu+Le41HN/6RLk8vGvMgWfd9gDANkYdyAe3JCdr89TodeAAx8B7",
+	"X4+35twRnFqFdncHvoRms+XUxl5Ue2NSZLBrFbzJOcXX8TN1UUz78f+puii2hadcuLWapzUu9Tty1G4M",
+	"rG1dT0xIyad8fASjya+bLhi9OrE/fvc8jnLG3V8vxm5G4F7IizinV3/57nlHXKD3oEfJqgRVZgPEtK3f",
+	"MtM7pbPqp+9YsM2R8thirNYtjwrv9MZ/O5AOCai4vubwNbK6Z2YztOcitFEc0UwCTZduYyO1EVaaNdpb",
+	"DeMdUwYDxyL7gqppLiS0A0zRjE54DjL+MlXs946fNZI0VFN0WT47GNH25Yj1KYtXo2tbincYr36omRuO",
+	"+HHO/lXBJAwzl7Q4B94jCGdei/0u2qhfV0q+IIipkHPK2e8gmyKF1g6DS5CtgvCR6mRx6EyWHu7vbeWr",
+	"7Kt1o68s57Usk04W9yLVoEnvyxH7/OO8hP3vdG9M8ikImYJ0DuuYeUbneMpS1Z5s0eVV3SDXon3Poyaj",
+	"TeI9Q7J1Q8s3G1NIMsY7tgk9j8xrKJV0ptea8XcfUelk4BSsTBbsoqP5n80OZciIu/WMODvxTz3jzM6C",
+	"px5/Qm+JJQZER8LDbk9yjcgeHtoGnajGx5E3yoldo9i0sYVOKWZsLH23tlndLzQwXLMfHDOuOyf+3qYp",
+	"Hi5F8FEn3n3ZutJWLY9b7+Hh2DXiXQOtFCqQDgIOHRHcaXX1RZMhocIBG3RtMbxqSC6WV6cr1c1uziny",
+	"OSSlZHr5CZfGztYZUAnyVakXdaWEUTPm8WoyFloXlvkYnwkn7F62y1tVQGKU3h//88f/gSIpJa8+npCC",
+	"SkoEOaPJ+TPgKT6mRWZf+29BioxyfgSSJIIrLcs//jelJC0l5RqIID+9+wf5myglhyV+eSqSc9AKqD6q",
+	"zZLjqGrDC6wfRy+Onh89N7uqBXBasOg4+tY8iqOC6oUZ+oSmOeMTM5Vq8rXK/rqeFKuEtjnozcGeAk9B",
+	"KqIXQKqvyExI+0CyIiaXTC9wdAksRJaCJGYzLCaMm5fsepEkEwqUJlqYp4IDoeocUmzsiLzFjfpVB2el",
+	"Jqt0PUJ5SvwkP8IBUkWoIeCIfODZkpgBKpLTJUlolhFmJg6FxQAG5sJiPP4VvmZTET673qqcPuPM0xw0",
+	"SBUd//I1YjgBOIUV8x37aXMrPrWcbDGgNQThmvqtBLn02nLJX/6nO5N125uqZaKbiC9IsQ3HGI745vnz",
+	"yGQFce2STmlhuBVna/Krsnpt1d7OdItqGo3kNJnoDcxomWlSx4Ou4+jl1v5dItm/D6TDpn1tEvBXmpIK",
+	"903fL26v7585LfVCSPY7pLbzb2+v8x+EPGNpCtz2/PL2ev5JaPKDKG1S9Xe3udgnXIPkNCOfQF6AJNWL",
+	"K51gpNvXBr98QflQZZ5TuaxRj1BO4JkBnBqZEOxEqYmykQEHM9ag+SUyIBR9wb46EVeD0s/wcxxgIVQL",
+	"6GJSrCKXC6oNVjqMJmLWhGFZYbOFVJqmEpSKiV5IUc4X5qEJOMxLCSmxA5GUq0JIHZOzZUGVwkHgi6LU",
+	"Z+JqAJh+FKoNTauk3sPB6Zc6R/KvIl3uF8TWU5LXbFAk7XoDSF8OoqEKCaElhKZL0yIK6BnQcx09X37z",
+	"zW1OeCFFAkohT5K3XCNe3hMIRyq+uV2+/5FquKTLYeoDEaRNeWiBTx1Od+qNUi8mxvj1FUQL9pZ6YXbZ",
+	"osPgYWP7MeBgCw4GsVxZVmu8j/6diSdZN864hI79rVA0uB+FaJ35Jxcg2WzZ6Zh+RsOIzv0OFEEP+Iic",
+	"aOctKsKFXjA+j4kS1v5RCeUcjSb0KQ1VhYQZ6GSBDxPK/0WTUgEpC2tr4X7xfxKmFfqkWnBj+Fl3+OOH",
+	"T58r20vRHMjPp+/afc5KUv/LDqndMlr3Dt1O9UC7aKuHh2Vdk6oYrbuhey9594/pF+KS6IojNRrucFXx",
+	"IG3KwDlwE0ChRIFSTHD7rEUg4j7of9c8dWMFU7HUQzB8/3x7fb8WfJax5H7y+1vH3ON5G8E+Bw/dN1Dz",
+	"vfUKD8R8fsy9J+t9aw2cTUP9vUjZjEF6D5yjEODpZ6H/CFVoxbBAFVrJxHwOKUauSwXSZ1v8W1lMLjus",
+	"EWftM+VHY8y/8WNsW2HLaHgwRVz6pAkoGfOEaXxs5SqtYzjGyJjkYANJhHGlgaYxuVywZEGSBSTnXh/i",
+	"ktu/OIaLOLREbcpKsPbvMbRuZgfP4b5FUILbMhwvLGuPgwyr6ib1Xm17zPetQY+V9Fb4QZ1uRZsyp+eg",
+	"ECj0Cm4cGVb8eWL+lRpAERjJRc/FHDaDvg1JMspyjOYSD7CUpku18mJKrjFmsQDegh5C6ff2SLkDYUjL",
+	"oXUBQQKCPHwEcXGRKhDi7OdNQR6GJ72jJK4T1+19jpY4fAmxkqcTK6n4rykNdJsR3hkYuSP2eXL6J4RC",
+	"LDu/bgNyy7prG/Pm3wZEL6lCZNREiy0AX9eodSJ7WSQixw38Gcu0SbxSWtWZWQbPiUDCl8bdpZpQCURp",
+	"lmVkLvBDujDepHU/M6AXoDATgNS1CQb5q4oEdClVB2h/rqvcdsqbpTaKWwSiGtHqBI2Sr2omvvROiFJC",
+	"6tYOqEpscXsyoDVXubdqLWec5WXul+F6xfTdjVR1f15L9Mq19NzW93a3e9BY7EaZ5n6DYk/UUn5oRiqy",
+	"gYcfm/aojTAJ6UewmiBWVbv6YOYflDD56v11kl5PnHj3NmHvs+3qn/Tg/fvkzWs3yD5JUY352WqWjMwW",
+	"DebyNrh46iH0rYa6ZWOMSnlcSgSvcrE9IGiejrLVZn86YvPk3IQ7y5kLDkrloIyX2Z2621VCP27d/cYN",
+	"MujugDYPWHc7WV3HAWvFo4QcRoU/OukJKjyo8FuW5jc3Ft2dmrzkqjxDus4euTb/2Rto0OgBfB6wRlda",
+	"FMoJoyL0TJQrIaxBweVjemy8bw3/KCUqaPknK2h7lypUvvVmXreAVTtoB0mr2riVr1dW1YuDEPCAMv5D",
+	"plNbRMmsJaEmT9HdstXc/vF4fvLVXil3be3JDDRssv8b89wIAP7n5E2/6nHT8I00SAD+UMwdKiW2bQlb",
+	"0WzxMCtJjzsreO5AnuPN84syipvUKdUU1TfkZ3Y3G03pSvRjkog8p0QBEqkhPbJHBGfmyix38labpcl4",
+	"kpVpM6OjPuG0PkTUtwYaF5/EG1dNdRx/etjkj467VkIGSIC4J1MM5sx6d3FhO84VVCeLlrCYqcqg5G+f",
+	"PvxEcpBz9NF1siD/au5F/PbP//Fv9dE9xnVgkKXqyLAok/gpJyezZ+/NN5iPB9KeqYZfvP1M542vzwDz",
+	"9lyZWFUHxpQJl7kz4WwPCHKpKNGW/K0Uui1xrz4/+65R+h/mqCNBUmFHvsJIG+mY4SFEotSKpVBXyNgF",
+	"o25kbfAsSj0Vs6nEuWrNBpTgLjx1humXePxpQ2bln5mVH8jbG8eYh4qXgOJ3f+rQXW2/vHxxi073RwmJ",
+	"4PbAVfIDZdk9KnF6+c2f7mgiTutDfR+eNjexaZplS2Jv897qurQWc98DxVzqoJb3o5bHlLAHXRx0cdDF",
+	"QRcHXbyfYxO6NfDmNsGkeWlvRyIKU0SK0pzHm2VEgi4lJ6iNUAthn4qcgb4E4CsFXV8YYPJQ3JUB9uWY",
+	"wIV5VajVEb8rQlozTDwF/coP6d3RvsXew4Etd56HiGDQX08tIthEgQq/Vk93ZM3cPUocKplg/Q6yO0ko",
+	"WBHxkI4RDOjzRKznkD4yHHvr7BIffped4LvVgpx8rb4fmoCyAutXVQu3GYdpaXg1kpDxEuA3wO/DqeMY",
+	"lWqzG/k6N6QHx6+rrg66ufy4EXX/VnbrheEhGh0APUSjQzQ6RKP3tzPcS9PuZZ/4MHq21EHL3nDDN6jZ",
+	"oGaDmg1qNqjZfW/6jgrgJTQDnlJ5xJLuTeDX7iVCi0KRugTZng7AFJ4MUF+TQaVk7nxhBYkETaou3KU1",
+	"5szW5tkK9kpr+4u7zSY2O8fm5ALCNKFzyjj2V7DkHE8vcGWiR8Qd2JD6TSqS4e4yTRJQLWq8uaNcDe4k",
+	"UbetvW/reIJqBcIRBY+lcrq6VMfKPU+NOevlDFJjKbNacGcAqQ8LW5JC7HnEPeqnK/Exr4ci0mAaBtPw",
+	"KYfULRDUtghPCReazey9OL5y7otDj+iwYh8vB5yz+gBNjXAS0tM5l3hLrUcvy+HRSEI4wSgo47s5athX",
+	"t6iXKmVozis0xPVVt+YLUL3t/hP3/sPOr7Sj8M5YGxSWfn5IOkKWZfBJumAwJDsO90+siJECRJFBZbX3",
+	"OMt1DSeNm7HtMm0PI9+Zdx9HnYoZSyhPCdD1ZMtTjOT7YOFOlOrr79w+GhyqHgVHcqe1KJaAYCEFmAl1",
+	"KI+uDgVhtQ1mu4yxiZApmL3NPR4xYlo2t3zGuJn249vPpLVzVxCt8Kb3HfliBv8/GFofthI4BTPjziIM",
+	"aVwB9UMaV0jjCmlco/WfA9SdjkanBvyK/xtad2nwG/9z12nLlviQFxJ0TdA1j73Ussu431fdB7Z3wJqP",
+	"R4eZhyrzGBwiCngd8Dr4BsE3CL7BthKPAbGxxlZmv/1K/965R3S8nj+ssIMZFMmT3cHsysLenfWw7ULb",
+	"QUGHzqst79ac3t9FmSESEQApANL2aGcuLmCtCHQmRd7vbu0ByDQBc7NoZwnJO6a0wvOI5bJKnP2thBJS",
+	"c+eozXuv24vxyH1QmsyYVBoLT2la310qKcfT+U0Jq9aQF9rWl5gzk7nQ5AyIBC2ZLcPra4Q1EPKtHcyj",
+	"xcm92n3ezNl5C+kiAUIflU1njl+HjBn0UprqUlVB0AqVFHBtL1IeeHtyT3g1BX5ullRnAsYHni3XwRT/",
+	"zhVkF2BK7lystkcSRSc6/tSg5TGB5KFCtN70NSYvhG0DModShwedT7cQ5naNBegFrF2eT+agt9y5P64o",
+	"YquWkKCAp70LyzoR/tS2E+IEAUoDlD6snOhb7PizEOQ95cuK1dTDDJGYYl4EY1uXW4UnDmjMS5GBZ8OP",
+	"MsJPsY1gew+yvXHOgskd9EQwuR+2yW0CGIjYCKSbpxwKPiq8bQM7ey5uOVx+3CdL7sOub1ndTGuHE9A5",
+	"oHOw4kMeW8hju4GCfE/leX1akSLYewYaUiIkoTJZsIuOE0qvr/9/AM4hR0V5BQEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
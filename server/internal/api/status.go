@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"server/internal/api/spec"
+)
+
+const (
+	tripDraft     = "draft"
+	tripConfirmed = "confirmed"
+	tripCancelled = "cancelled"
+	tripCompleted = "completed"
+	tripArchived  = "archived"
+)
+
+const (
+	rsvpPending   = "pending"
+	rsvpConfirmed = "confirmed"
+	rsvpDeclined  = "declined"
+)
+
+// tripTransitions lists the statuses a trip can move to from each status.
+// Archived is final.
+var tripTransitions = map[string][]string{
+	tripDraft:     {tripConfirmed, tripCancelled},
+	tripConfirmed: {tripCancelled, tripCompleted},
+	tripCancelled: {tripArchived},
+	tripCompleted: {tripArchived},
+}
+
+func canTransition(from, to string) bool {
+	for _, s := range tripTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tripLocked returns the problem to reply with to a change to the trip, its
+// activities or its links once its status no longer allows one.
+func tripLocked(status string) *apiError {
+	switch status {
+	case tripCancelled, tripCompleted, tripArchived:
+		return &apiError{http.StatusConflict, codeTripStatusConflict, "Trip is " + status + " and can no longer be changed"}
+	}
+
+	return nil
+}
+
+// tripIsConfirmed backs the is_confirmed field older clients still read.
+func tripIsConfirmed(status string) bool {
+	return status == tripConfirmed || status == tripCompleted
+}
+
+func tripStatus(status string) spec.TripStatus {
+	var s spec.TripStatus
+	_ = s.FromValue(status)
+	return s
+}
+
+func rsvpStatus(status string) spec.RsvpStatus {
+	var s spec.RsvpStatus
+	_ = s.FromValue(status)
+	return s
+}
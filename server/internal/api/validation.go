@@ -0,0 +1,95 @@
+package api
+
+import (
+	"reflect"
+	"server/internal/api/spec"
+	"server/internal/pgstore"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// maxTripDuration is the longest a trip can last.
+const maxTripDuration = 365 * 24 * time.Hour
+
+// activityDate ties the time of an activity to the trip it belongs to, so
+// validating it checks the activity happens during the trip.
+type activityDate struct {
+	OccursAt time.Time          `json:"occurs_at"`
+	Trip     pgstore.GetTripRow `json:"-"`
+}
+
+// registerValidations adds the rules that span several fields, or a request
+// and stored data, to v.
+func registerValidations(v *validator.Validate) {
+	// Report fields by the name clients send them with.
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	v.RegisterStructValidation(validateCreateTrip, spec.CreateTripRequest{})
+	v.RegisterStructValidation(validateUpdateTrip, spec.UpdateTripRequest{})
+	v.RegisterStructValidation(validateActivityDate, activityDate{})
+}
+
+func validateCreateTrip(sl validator.StructLevel) {
+	trip := sl.Current().Interface().(spec.CreateTripRequest)
+	if !validateTripDates(sl, trip.StartsAt, trip.EndsAt) {
+		return
+	}
+
+	// A trip can start today wherever it takes place, but not earlier.
+	var timezone string
+	if trip.Timezone != nil {
+		timezone = *trip.Timezone
+	}
+	loc := pgstore.Location(timezone)
+	if startOfDay(trip.StartsAt, loc).Before(startOfDay(time.Now(), loc)) {
+		sl.ReportError(trip.StartsAt, "starts_at", "StartsAt", "not_past", "")
+	}
+}
+
+func validateUpdateTrip(sl validator.StructLevel) {
+	trip := sl.Current().Interface().(spec.UpdateTripRequest)
+	validateTripDates(sl, trip.StartsAt, trip.EndsAt)
+}
+
+// validateTripDates reports trips that end before they start or last longer
+// than maxTripDuration. It returns false when the dates are not usable.
+func validateTripDates(sl validator.StructLevel, startsAt, endsAt time.Time) bool {
+	if startsAt.IsZero() || endsAt.IsZero() {
+		return false
+	}
+
+	if endsAt.Before(startsAt) {
+		sl.ReportError(endsAt, "ends_at", "EndsAt", "gtefield", "starts_at")
+		return false
+	}
+
+	if endsAt.Sub(startsAt) > maxTripDuration {
+		sl.ReportError(endsAt, "ends_at", "EndsAt", "max_duration", maxTripDuration.String())
+		return false
+	}
+
+	return true
+}
+
+func validateActivityDate(sl validator.StructLevel) {
+	act := sl.Current().Interface().(activityDate)
+	loc := pgstore.Location(act.Trip.Timezone)
+	if !withinTrip(act.OccursAt, act.Trip.StartsAt.Time, act.Trip.EndsAt.Time, loc) {
+		sl.ReportError(act.OccursAt, "occurs_at", "OccursAt", "within_trip", "")
+	}
+}
+
+// withinTrip reports whether t falls on one of the calendar days, in loc, of
+// a trip from startsAt to endsAt.
+func withinTrip(t, startsAt, endsAt time.Time, loc *time.Location) bool {
+	day := startOfDay(t, loc)
+	return !day.Before(startOfDay(startsAt, loc)) && !day.After(startOfDay(endsAt, loc))
+}
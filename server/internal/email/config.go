@@ -0,0 +1,108 @@
+package email
+
+import (
+	"fmt"
+	netmail "net/mail"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/wneessen/go-mail"
+)
+
+// Config is how e-mails are sent. The defaults deliver to the mailpit
+// container of compose.yml.
+type Config struct {
+	// Transport is "smtp" or "file".
+	Transport string
+
+	Host        string
+	Port        int
+	TLSPolicy   mail.TLSPolicy
+	ImplicitTLS bool
+	Auth        mail.SMTPAuthType
+	Username    string
+	Password    string
+
+	// Dir is where the file transport writes messages.
+	Dir string
+
+	From string
+}
+
+// ConfigFromEnv reads the MAIL_* environment variables:
+//
+//	MAIL_TRANSPORT  smtp (default) or file
+//	MAIL_HOST       SMTP host, mailpit by default
+//	MAIL_PORT       SMTP port, 1025 by default
+//	MAIL_TLS        none (default), opportunistic, starttls or implicit
+//	MAIL_AUTH       plain (default), login or cram-md5, used with MAIL_USERNAME
+//	MAIL_USERNAME   SMTP user, no authentication when empty
+//	MAIL_PASSWORD   SMTP password
+//	MAIL_DIR        directory of the file transport, mail by default
+//	MAIL_FROM       sender address, no-reply@travelplanner.com by default
+func ConfigFromEnv() (Config, error) {
+	conf := Config{
+		Transport: envOr("MAIL_TRANSPORT", "smtp"),
+		Host:      envOr("MAIL_HOST", "mailpit"),
+		Username:  os.Getenv("MAIL_USERNAME"),
+		Password:  os.Getenv("MAIL_PASSWORD"),
+		Dir:       envOr("MAIL_DIR", "mail"),
+		From:      envOr("MAIL_FROM", "no-reply@travelplanner.com"),
+	}
+
+	if conf.Transport != "smtp" && conf.Transport != "file" {
+		return Config{}, fmt.Errorf("Email: MAIL_TRANSPORT must be smtp or file, got %q", conf.Transport)
+	}
+
+	port, err := strconv.Atoi(envOr("MAIL_PORT", "1025"))
+	if err != nil || port < 1 || port > 65535 {
+		return Config{}, fmt.Errorf("Email: MAIL_PORT must be a port number, got %q", os.Getenv("MAIL_PORT"))
+	}
+	conf.Port = port
+
+	switch tls := envOr("MAIL_TLS", "none"); tls {
+	case "none":
+		conf.TLSPolicy = mail.NoTLS
+	case "opportunistic":
+		conf.TLSPolicy = mail.TLSOpportunistic
+	case "starttls":
+		conf.TLSPolicy = mail.TLSMandatory
+	case "implicit":
+		conf.TLSPolicy = mail.TLSMandatory
+		conf.ImplicitTLS = true
+	default:
+		return Config{}, fmt.Errorf("Email: MAIL_TLS must be none, opportunistic, starttls or implicit, got %q", tls)
+	}
+
+	switch auth := envOr("MAIL_AUTH", "plain"); auth {
+	case "plain", "login", "cram-md5":
+		conf.Auth = mail.SMTPAuthType(strings.ToUpper(auth))
+	default:
+		return Config{}, fmt.Errorf("Email: MAIL_AUTH must be plain, login or cram-md5, got %q", auth)
+	}
+
+	_, err = netmail.ParseAddress(conf.From)
+	if err != nil {
+		return Config{}, fmt.Errorf("Email: MAIL_FROM must be an e-mail address: %w", err)
+	}
+
+	return conf, nil
+}
+
+// NewTransport returns the transport conf asks for.
+func NewTransport(conf Config) (Transport, error) {
+	if conf.Transport == "file" {
+		return NewFileTransport(conf.Dir)
+	}
+
+	return NewSMTPTransport(conf)
+}
+
+func envOr(key string, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+
+	return fallback
+}
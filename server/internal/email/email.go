@@ -1,9 +1,13 @@
 package email
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"server/internal/auth"
+	"server/internal/ical"
 	"server/internal/pgstore"
+	"server/internal/tripdiff"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,118 +16,292 @@ import (
 )
 
 type store interface {
-	GetTrip(context.Context, uuid.UUID) (pgstore.Trip, error)
+	GetTrip(context.Context, uuid.UUID) (pgstore.GetTripRow, error)
+	GetTripActivities(context.Context, uuid.UUID) ([]pgstore.Activity, error)
+	GetUserLocales(context.Context, []string) ([]pgstore.GetUserLocalesRow, error)
 }
 
 type Email struct {
-	store  store
-	client *mail.Client
+	store     store
+	transport Transport
+	from      string
 }
 
-func NewEmail(pool *pgxpool.Pool, client *mail.Client) Email {
-	return Email{pgstore.New(pool), client}
+// NewEmail sends e-mails through transport, from the address from.
+func NewEmail(pool *pgxpool.Pool, transport Transport, from string) Email {
+	return Email{pgstore.New(pool), transport, from}
 }
 
-func (m Email) getTripDetails(tripID uuid.UUID) (pgstore.Trip, error) {
-	const timeout = 30 * time.Second
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+// tripData is the trip as the templates show it, its dates in the trip's own
+// timezone.
+type tripData struct {
+	OwnerName   string
+	OwnerEmail  string
+	Destination string
+	StartsAt    time.Time
+	EndsAt      time.Time
+}
+
+type confirmTripData struct {
+	Trip          tripData
+	Link          string
+	ExpiresInDays int
+}
+
+type inviteData struct {
+	Trip          tripData
+	ConfirmLink   string
+	DeclineLink   string
+	CalendarLink  string
+	ExpiresInDays int
+}
+
+type changeEmailData struct {
+	Link           string
+	ExpiresInHours int
+}
+
+type loginLinkData struct {
+	Link             string
+	ExpiresInMinutes int
+}
+
+type tripCancelledData struct {
+	Trip tripData
+}
+
+type tripUpdatedData struct {
+	Trip            tripData
+	Changes         []tripdiff.Change
+	UnsubscribeLink string
+}
+
+func (m Email) send(ctx context.Context, msg *mail.Msg) error {
+	return m.sendAll(ctx, msg)[0]
+}
 
+func (m Email) sendAll(ctx context.Context, msgs ...*mail.Msg) []error {
+	return m.transport.Send(ctx, msgs...)
+}
+
+func (m Email) getTripDetails(ctx context.Context, tripID uuid.UUID) (tripData, error) {
+	trip, _, err := m.getTrip(ctx, tripID, false)
+	return trip, err
+}
+
+// getTrip loads the trip as the templates show it, and with withCalendar its
+// activities too, returning the trip as an iCalendar file to attach to
+// e-mails.
+func (m Email) getTrip(ctx context.Context, tripID uuid.UUID, withCalendar bool) (tripData, []byte, error) {
 	trip, err := m.store.GetTrip(ctx, tripID)
 	if err != nil {
-		return pgstore.Trip{}, err
+		return tripData{}, nil, err
 	}
 
-	return trip, nil
+	// Trip dates are shown as calendar days of the trip's own timezone.
+	loc := pgstore.Location(trip.Timezone)
+
+	data := tripData{
+		OwnerName:   trip.OwnerName.String,
+		OwnerEmail:  trip.OwnerEmail,
+		Destination: trip.Destination,
+		StartsAt:    trip.StartsAt.Time.In(loc),
+		EndsAt:      trip.EndsAt.Time.In(loc),
+	}
+	if !withCalendar {
+		return data, nil, nil
+	}
+
+	activities, err := m.store.GetTripActivities(ctx, tripID)
+	if err != nil {
+		return tripData{}, nil, err
+	}
+
+	cal := ical.TripCalendar(trip, activities)
+	cal.Method = "PUBLISH"
+	return data, cal.Encode(time.Now()), nil
+}
+
+// attachCalendar attaches the iCalendar file from getTrip to msg.
+func attachCalendar(msg *mail.Msg, calendar []byte) {
+	msg.AttachReadSeeker("trip.ics", bytes.NewReader(calendar),
+		mail.WithFileContentType(ical.ContentType+"; method=PUBLISH"))
 }
 
-func (m Email) SendConfirmTripEmailToTripOwner(tripID uuid.UUID) error {
-	trip, err := m.getTripDetails(tripID)
+// getLocales returns the locale to write to each of the e-mails in, by the
+// preference on the recipient's profile.
+func (m Email) getLocales(ctx context.Context, emails ...string) (map[string]string, error) {
+	users, err := m.store.GetUserLocales(ctx, emails)
 	if err != nil {
-		return fmt.Errorf("Email: failed to get trip for SendConfirmTripEmailToTripOwner: %w", err)
+		return nil, err
+	}
+
+	locales := make(map[string]string, len(emails))
+	for _, email := range emails {
+		locales[email] = templates.locale("")
+	}
+	for _, u := range users {
+		locales[u.Email] = templates.locale(u.Locale.String)
+	}
+
+	return locales, nil
+}
+
+// newMsg renders the e-mail name to one recipient as a text message with an
+// HTML alternative. op names the caller in errors.
+func (m Email) newMsg(op string, to string, name string, locale string, data any) (*mail.Msg, error) {
+	r, err := templates.render(name, locale, data)
+	if err != nil {
+		return nil, fmt.Errorf("Email: failed to render email for %s: %w", op, err)
 	}
 
 	msg := mail.NewMsg()
-	err = msg.From("no-reply@travelplanner.com")
+	err = msg.From(m.from)
+	if err != nil {
+		return nil, fmt.Errorf("Email: failed to set From in email for %s: %w", op, err)
+	}
+	err = msg.To(to)
 	if err != nil {
-		return fmt.Errorf("Email: failed to set From in email for SendConfirmTripEmailToTripOwner: %w", err)
+		return nil, fmt.Errorf("Email: failed to set To in email for %s: %w", op, err)
 	}
-	err = msg.To(trip.OwnerEmail)
+	msg.Subject(r.subject)
+	msg.SetBodyString(mail.TypeTextPlain, r.text)
+	msg.AddAlternativeString(mail.TypeTextHTML, r.html)
+
+	return msg, nil
+}
+
+// sendOne renders the e-mail name to one recipient, in their locale, and
+// sends it.
+func (m Email) sendOne(ctx context.Context, op string, to string, name string, data any) error {
+	locales, err := m.getLocales(ctx, to)
+	if err != nil {
+		return fmt.Errorf("Email: failed to get recipient locale for %s: %w", op, err)
+	}
+
+	msg, err := m.newMsg(op, to, name, locales[to], data)
 	if err != nil {
-		return fmt.Errorf("Email: failed to set To in email for SendConfirmTripEmailToTripOwner: %w", err)
-	}
-	msg.Subject(fmt.Sprintf("Confirm your trip to %s", trip.Destination))
-	body := fmt.Sprintf(`
-		Hello, %s!
-		
-		Your trip to %s needs confirmation!
-		
-		Trip Details:
-		ID: %s
-		Destination: %s
-		Starts At: %s
-		Ends At: %s
-		
-		Best regards,
-		Travel Planner`,
-		trip.OwnerName,
-		trip.Destination,
-		trip.ID,
-		trip.Destination,
-		trip.StartsAt.Time.Format(time.DateOnly),
-		trip.EndsAt.Time.Format(time.DateOnly),
-	)
-	msg.SetBodyString(mail.TypeTextPlain, body)
-
-	err = m.client.DialAndSend(msg)
+		return err
+	}
+
+	err = m.send(ctx, msg)
 	if err != nil {
-		return fmt.Errorf("Email: failed to send e-mail message for SendConfirmTripEmailToTripOwner: %w", err)
+		return fmt.Errorf("Email: failed to send e-mail message for %s: %w", op, err)
 	}
 
 	return nil
 }
 
-func (m Email) SendInviteToTripEmail(tripID uuid.UUID, email string) error {
-	trip, err := m.getTripDetails(tripID)
+func (m Email) SendConfirmTripEmailToTripOwner(ctx context.Context, tripID uuid.UUID, link string) error {
+	trip, err := m.getTripDetails(ctx, tripID)
 	if err != nil {
-		return fmt.Errorf("Email: failed to get trip for SendInviteToTripEmail: %w", err)
+		return fmt.Errorf("Email: failed to get trip for SendConfirmTripEmailToTripOwner: %w", err)
 	}
 
-	msg := mail.NewMsg()
-	err = msg.From("no-reply@travelplanner.com")
+	return m.sendOne(ctx, "SendConfirmTripEmailToTripOwner", trip.OwnerEmail, "confirm_trip", confirmTripData{
+		Trip:          trip,
+		Link:          link,
+		ExpiresInDays: int(auth.ConfirmationTokenTTL.Hours() / 24),
+	})
+}
+
+// Invite is an invitation e-mail to one participant. CalendarLink is the
+// participant's feed of the trip calendar, left out of the e-mail when empty.
+type Invite struct {
+	Email        string
+	ConfirmLink  string
+	DeclineLink  string
+	CalendarLink string
+}
+
+// SendInvites e-mails the invites to a trip, with the trip calendar attached,
+// loading the trip once and sending them all over a single connection. It
+// returns an error for each invite, nil for those sent.
+func (m Email) SendInvites(ctx context.Context, tripID uuid.UUID, invites []Invite) []error {
+	errs := make([]error, len(invites))
+
+	trip, calendar, err := m.getTrip(ctx, tripID, true)
 	if err != nil {
-		return fmt.Errorf("Email: failed to set From in email for SendInviteToTripEmail: %w", err)
+		return fillErrors(errs, fmt.Errorf("Email: failed to get trip for SendInvites: %w", err))
 	}
-	err = msg.To(email)
+
+	emails := make([]string, len(invites))
+	for i, invite := range invites {
+		emails[i] = invite.Email
+	}
+
+	locales, err := m.getLocales(ctx, emails...)
 	if err != nil {
-		return fmt.Errorf("Email: failed to set To in email for SendInviteToTripEmail: %w", err)
-	}
-	msg.Subject(fmt.Sprintf("You are invited on a trip to %s!", trip.Destination))
-	body := fmt.Sprintf(`
-		Hey!
-		%s is inviting you for a trip to %s and is waiting for your confirmation!
-
-		Trip Details:
-		ID: %s
-		Destination: %s
-		Starts At: %s
-		Ends At: %s
-		
-		Best regards,
-		Travel Planner`,
-		trip.OwnerName,
-		trip.Destination,
-		trip.ID,
-		trip.Destination,
-		trip.StartsAt.Time.Format(time.DateOnly),
-		trip.EndsAt.Time.Format(time.DateOnly),
-	)
-	msg.SetBodyString(mail.TypeTextPlain, body)
-
-	err = m.client.DialAndSend(msg)
+		return fillErrors(errs, fmt.Errorf("Email: failed to get recipient locales for SendInvites: %w", err))
+	}
+
+	// Messages that can't be built are left out of the batch, sent[j] is the
+	// index of the invite msgs[j] is for.
+	var msgs []*mail.Msg
+	var sent []int
+	for i, invite := range invites {
+		msg, err := m.newMsg("SendInvites", invite.Email, "invite", locales[invite.Email], inviteData{
+			Trip:          trip,
+			ConfirmLink:   invite.ConfirmLink,
+			DeclineLink:   invite.DeclineLink,
+			CalendarLink:  invite.CalendarLink,
+			ExpiresInDays: int(auth.ConfirmationTokenTTL.Hours() / 24),
+		})
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		attachCalendar(msg, calendar)
+
+		msgs = append(msgs, msg)
+		sent = append(sent, i)
+	}
+
+	if len(msgs) == 0 {
+		return errs
+	}
+
+	for j, err := range m.sendAll(ctx, msgs...) {
+		if err != nil {
+			errs[sent[j]] = fmt.Errorf("Email: failed to send e-mail message for SendInvites: %w", err)
+		}
+	}
+
+	return errs
+}
+
+func (m Email) SendChangeEmailLink(ctx context.Context, email string, link string) error {
+	return m.sendOne(ctx, "SendChangeEmailLink", email, "change_email", changeEmailData{
+		Link:           link,
+		ExpiresInHours: int(auth.EmailChangeTokenTTL.Hours()),
+	})
+}
+
+func (m Email) SendLoginLinkEmail(ctx context.Context, email string, link string) error {
+	return m.sendOne(ctx, "SendLoginLinkEmail", email, "login_link", loginLinkData{
+		Link:             link,
+		ExpiresInMinutes: int(auth.LoginTokenTTL.Minutes()),
+	})
+}
+
+func (m Email) SendTripCancelledEmail(ctx context.Context, tripID uuid.UUID, email string) error {
+	trip, err := m.getTripDetails(ctx, tripID)
 	if err != nil {
-		return fmt.Errorf("Email: failed to send e-mail message for SendInviteToTripEmail: %w", err)
+		return fmt.Errorf("Email: failed to get trip for SendTripCancelledEmail: %w", err)
 	}
 
-	return nil
+	return m.sendOne(ctx, "SendTripCancelledEmail", email, "trip_cancelled", tripCancelledData{Trip: trip})
+}
+
+func (m Email) SendTripUpdatedEmail(ctx context.Context, tripID uuid.UUID, email string, changes []tripdiff.Change, unsubscribeLink string) error {
+	trip, err := m.getTripDetails(ctx, tripID)
+	if err != nil {
+		return fmt.Errorf("Email: failed to get trip for SendTripUpdatedEmail: %w", err)
+	}
+
+	return m.sendOne(ctx, "SendTripUpdatedEmail", email, "trip_updated", tripUpdatedData{
+		Trip:            trip,
+		Changes:         changes,
+		UnsubscribeLink: unsubscribeLink,
+	})
 }
@@ -0,0 +1,162 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"server/internal/pgstore"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type fakeStore struct {
+	trip       pgstore.GetTripRow
+	activities []pgstore.Activity
+	locales    map[string]string
+	// tripLoads, when set, counts the calls to GetTrip.
+	tripLoads *int
+}
+
+func (s fakeStore) GetTrip(context.Context, uuid.UUID) (pgstore.GetTripRow, error) {
+	if s.tripLoads != nil {
+		*s.tripLoads++
+	}
+
+	return s.trip, nil
+}
+
+func (s fakeStore) GetTripActivities(context.Context, uuid.UUID) ([]pgstore.Activity, error) {
+	return s.activities, nil
+}
+
+func (s fakeStore) GetUserLocales(_ context.Context, emails []string) ([]pgstore.GetUserLocalesRow, error) {
+	var rows []pgstore.GetUserLocalesRow
+	for _, email := range emails {
+		if locale, ok := s.locales[email]; ok {
+			rows = append(rows, pgstore.GetUserLocalesRow{Email: email, Locale: pgtype.Text{Valid: true, String: locale}})
+		}
+	}
+
+	return rows, nil
+}
+
+func TestSendInvitesUsesRecipientLocale(t *testing.T) {
+	recorder := &Recorder{}
+	var tripLoads int
+	m := Email{
+		store: fakeStore{
+			trip: pgstore.GetTripRow{
+				Destination: "Lisbon",
+				OwnerName:   pgtype.Text{Valid: true, String: "Ana"},
+				Timezone:    "Europe/Lisbon",
+			},
+			locales:   map[string]string{"joao@example.com": "pt-BR"},
+			tripLoads: &tripLoads,
+		},
+		transport: recorder,
+		from:      "no-reply@travelplanner.test",
+	}
+
+	errs := m.SendInvites(context.Background(), uuid.New(), []Invite{
+		{Email: "jane@example.com", ConfirmLink: "https://confirm/1", DeclineLink: "https://decline/1"},
+		{Email: "joao@example.com", ConfirmLink: "https://confirm/2", DeclineLink: "https://decline/2"},
+	})
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("invite %d: %v", i, err)
+		}
+	}
+
+	if tripLoads != 1 {
+		t.Errorf("loaded the trip %d times, want once per batch", tripLoads)
+	}
+
+	msgs := recorder.Messages()
+	if len(msgs) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(msgs))
+	}
+
+	wantSubjects := []string{
+		"You are invited on a trip to Lisbon!",
+		"Você foi convidado para uma viagem para Lisbon!",
+	}
+	for i, msg := range msgs {
+		header := msg.GetGenHeader("Subject")
+		if len(header) != 1 {
+			t.Fatalf("message %d has %d subjects", i, len(header))
+		}
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(header[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if subject != wantSubjects[i] {
+			t.Errorf("message %d has subject %q, want %q", i, subject, wantSubjects[i])
+		}
+
+		var buf bytes.Buffer
+		_, err = msg.WriteTo(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		raw := buf.String()
+		for _, part := range []string{"multipart/alternative", "text/plain", "text/html", "text/calendar"} {
+			if !strings.Contains(raw, part) {
+				t.Errorf("message %d has no %s part", i, part)
+			}
+		}
+	}
+}
+
+func TestPreview(t *testing.T) {
+	recorder := &Recorder{}
+	m := Email{
+		store: fakeStore{
+			trip: pgstore.GetTripRow{
+				Destination: "Lisbon",
+				Timezone:    "Europe/Lisbon",
+			},
+		},
+		transport: recorder,
+		from:      "no-reply@travelplanner.test",
+	}
+
+	_, err := m.Preview(context.Background(), "welcome", uuid.New(), "")
+	if !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Preview of unknown template returned %v, want ErrUnknownTemplate", err)
+	}
+
+	_, err = m.Preview(context.Background(), "invite", uuid.Nil, "")
+	if !errors.Is(err, ErrTripRequired) {
+		t.Errorf("Preview of invite without trip returned %v, want ErrTripRequired", err)
+	}
+
+	r, err := m.Preview(context.Background(), "login_link", uuid.Nil, "pt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Locale != "pt-BR" {
+		t.Errorf("Preview rendered in %s, want pt-BR", r.Locale)
+	}
+
+	r, err = m.Preview(context.Background(), "trip_updated", uuid.New(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(r.Text, previewLink) || !strings.Contains(r.HTML, previewLink) {
+		t.Error("Preview links are not placeholders")
+	}
+
+	err = m.SendPreview(context.Background(), "trip_cancelled", uuid.New(), "", "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.Messages()) != 1 {
+		t.Errorf("sent %d messages, want 1", len(recorder.Messages()))
+	}
+}
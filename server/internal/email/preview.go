@@ -0,0 +1,142 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"server/internal/auth"
+	"server/internal/tripdiff"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrUnknownTemplate is returned for a name that is none of the e-mails.
+	ErrUnknownTemplate = errors.New("Email: unknown template")
+	// ErrTripRequired is returned when previewing an e-mail about a trip
+	// without one.
+	ErrTripRequired = errors.New("Email: template needs a trip")
+	// ErrSend is returned when the transport fails to deliver a test e-mail.
+	ErrSend = errors.New("Email: failed to send e-mail message for SendPreview")
+)
+
+// previewLink stands in for the links in previews, which must not carry real
+// tokens.
+const previewLink = "https://example.com/preview"
+
+// Rendered is an e-mail as its recipient sees it.
+type Rendered struct {
+	Locale  string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Preview renders the e-mail name about the trip, in the locale closest to
+// the preference, without sending it. Links are placeholders, and the trip
+// updated e-mail lists made-up changes to the trip's dates. tripID is ignored
+// by the login link and e-mail change e-mails, which are about no trip.
+func (m Email) Preview(ctx context.Context, name string, tripID uuid.UUID, preference string) (Rendered, error) {
+	if !slices.Contains(templateNames, name) {
+		return Rendered{}, ErrUnknownTemplate
+	}
+
+	data, _, err := m.previewData(ctx, name, tripID)
+	if err != nil {
+		return Rendered{}, err
+	}
+
+	locale := templates.locale(preference)
+	r, err := templates.render(name, locale, data)
+	if err != nil {
+		return Rendered{}, fmt.Errorf("Email: failed to render email for Preview: %w", err)
+	}
+
+	return Rendered{locale, r.subject, r.text, r.html}, nil
+}
+
+// SendPreview sends the e-mail Preview renders to the address to, through the
+// configured transport, with the attachments it would have.
+func (m Email) SendPreview(ctx context.Context, name string, tripID uuid.UUID, preference string, to string) error {
+	if !slices.Contains(templateNames, name) {
+		return ErrUnknownTemplate
+	}
+
+	data, calendar, err := m.previewData(ctx, name, tripID)
+	if err != nil {
+		return err
+	}
+
+	msg, err := m.newMsg("SendPreview", to, name, templates.locale(preference), data)
+	if err != nil {
+		return err
+	}
+
+	if calendar != nil {
+		attachCalendar(msg, calendar)
+	}
+
+	err = m.send(ctx, msg)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSend, err)
+	}
+
+	return nil
+}
+
+// previewData returns the data to render the e-mail name with, and the trip
+// calendar for the e-mails that attach it.
+func (m Email) previewData(ctx context.Context, name string, tripID uuid.UUID) (any, []byte, error) {
+	switch name {
+	case "change_email":
+		return changeEmailData{
+			Link:           previewLink,
+			ExpiresInHours: int(auth.EmailChangeTokenTTL.Hours()),
+		}, nil, nil
+	case "login_link":
+		return loginLinkData{
+			Link:             previewLink,
+			ExpiresInMinutes: int(auth.LoginTokenTTL.Minutes()),
+		}, nil, nil
+	}
+
+	if tripID == uuid.Nil {
+		return nil, nil, ErrTripRequired
+	}
+
+	trip, calendar, err := m.getTrip(ctx, tripID, name == "invite")
+	if err != nil {
+		return nil, nil, fmt.Errorf("Email: failed to get trip for Preview: %w", err)
+	}
+
+	switch name {
+	case "confirm_trip":
+		return confirmTripData{
+			Trip:          trip,
+			Link:          previewLink,
+			ExpiresInDays: int(auth.ConfirmationTokenTTL.Hours() / 24),
+		}, nil, nil
+	case "invite":
+		return inviteData{
+			Trip:          trip,
+			ConfirmLink:   previewLink,
+			DeclineLink:   previewLink,
+			CalendarLink:  previewLink,
+			ExpiresInDays: int(auth.ConfirmationTokenTTL.Hours() / 24),
+		}, calendar, nil
+	case "trip_cancelled":
+		return tripCancelledData{Trip: trip}, nil, nil
+	case "trip_updated":
+		return tripUpdatedData{
+			Trip: trip,
+			Changes: []tripdiff.Change{
+				{Field: tripdiff.FieldStartsAt, OldDate: trip.StartsAt.AddDate(0, 0, -1), NewDate: trip.StartsAt},
+				{Field: tripdiff.FieldEndsAt, OldDate: trip.EndsAt.AddDate(0, 0, -1), NewDate: trip.EndsAt},
+			},
+			UnsubscribeLink: previewLink,
+		}, nil, nil
+	}
+
+	return nil, nil, ErrUnknownTemplate
+}
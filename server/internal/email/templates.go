@@ -0,0 +1,131 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// locales are the languages e-mails are written in, the first being the one
+// used when the recipient's preference matches none of them.
+var locales = []string{"en", "pt-BR"}
+
+// templateNames are the e-mails, each with a .txt.tmpl and a .html.tmpl file
+// per locale. The text file defines "subject" and "content", the HTML file
+// "content", and both are wrapped in the layout of their format.
+var templateNames = []string{
+	"change_email",
+	"confirm_trip",
+	"invite",
+	"login_link",
+	"trip_cancelled",
+	"trip_updated",
+}
+
+// dateLayouts are how each locale writes trip dates.
+var dateLayouts = map[string]string{
+	"en":    "January 2, 2006",
+	"pt-BR": "02/01/2006",
+}
+
+var templates = mustParseTemplates()
+
+type templateSet struct {
+	matcher language.Matcher
+	text    map[string]*texttemplate.Template
+	html    map[string]*htmltemplate.Template
+}
+
+type rendered struct {
+	subject string
+	text    string
+	html    string
+}
+
+func mustParseTemplates() *templateSet {
+	tags := make([]language.Tag, len(locales))
+	for i, locale := range locales {
+		tags[i] = language.MustParse(locale)
+	}
+
+	set := &templateSet{
+		matcher: language.NewMatcher(tags),
+		text:    make(map[string]*texttemplate.Template),
+		html:    make(map[string]*htmltemplate.Template),
+	}
+
+	for _, locale := range locales {
+		funcs := map[string]any{
+			"lang": func() string { return locale },
+			"date": func(t time.Time) string { return t.Format(dateLayouts[locale]) },
+		}
+
+		for _, name := range templateNames {
+			key := locale + "/" + name
+			set.text[key] = texttemplate.Must(texttemplate.New("layout.txt.tmpl").Funcs(funcs).ParseFS(templateFS,
+				"templates/layout.txt.tmpl",
+				"templates/"+locale+"/common.txt.tmpl",
+				"templates/"+locale+"/"+name+".txt.tmpl",
+			))
+			set.html[key] = htmltemplate.Must(htmltemplate.New("layout.html.tmpl").Funcs(funcs).ParseFS(templateFS,
+				"templates/layout.html.tmpl",
+				"templates/"+locale+"/common.html.tmpl",
+				"templates/"+locale+"/"+name+".html.tmpl",
+			))
+		}
+	}
+
+	return set
+}
+
+// locale returns the locale closest to the recipient's preference, a BCP 47
+// language tag that may be empty.
+func (s *templateSet) locale(preference string) string {
+	tag, err := language.Parse(preference)
+	if err != nil {
+		return locales[0]
+	}
+
+	_, i, confidence := s.matcher.Match(tag)
+	if confidence == language.No {
+		return locales[0]
+	}
+
+	return locales[i]
+}
+
+// render executes the e-mail name in locale with data.
+func (s *templateSet) render(name string, locale string, data any) (rendered, error) {
+	key := locale + "/" + name
+	text, ok := s.text[key]
+	if !ok {
+		return rendered{}, fmt.Errorf("Email: no template %s", key)
+	}
+
+	var subject, body, html bytes.Buffer
+	err := text.ExecuteTemplate(&subject, "subject", data)
+	if err != nil {
+		return rendered{}, fmt.Errorf("Email: failed to render subject of %s: %w", key, err)
+	}
+
+	err = text.Execute(&body, data)
+	if err != nil {
+		return rendered{}, fmt.Errorf("Email: failed to render text of %s: %w", key, err)
+	}
+
+	err = s.html[key].Execute(&html, data)
+	if err != nil {
+		return rendered{}, fmt.Errorf("Email: failed to render HTML of %s: %w", key, err)
+	}
+
+	return rendered{strings.TrimSpace(subject.String()), body.String(), html.String()}, nil
+}
@@ -0,0 +1,169 @@
+package email
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"server/internal/tripdiff"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the current output")
+
+var sampleTrip = tripData{
+	OwnerName:   "Diego Fernandes",
+	OwnerEmail:  "diego@example.com",
+	Destination: "Florianópolis, Brazil",
+	StartsAt:    time.Date(2024, time.July, 10, 0, 0, 0, 0, time.UTC),
+	EndsAt:      time.Date(2024, time.July, 17, 0, 0, 0, 0, time.UTC),
+}
+
+// sampleData is what every template is rendered with in the golden files.
+var sampleData = map[string]any{
+	"change_email": changeEmailData{
+		Link:           "https://travelplanner.test/me/email/verify?token=abc",
+		ExpiresInHours: 24,
+	},
+	"confirm_trip": confirmTripData{
+		Trip:          sampleTrip,
+		Link:          "https://travelplanner.test/trips/1/confirm?token=abc",
+		ExpiresInDays: 7,
+	},
+	"invite": inviteData{
+		Trip:          sampleTrip,
+		ConfirmLink:   "https://travelplanner.test/participants/2/confirm?token=abc",
+		DeclineLink:   "https://travelplanner.test/participants/2/decline?token=abc",
+		CalendarLink:  "https://travelplanner.test/trips/1/calendar.ics?token=abc",
+		ExpiresInDays: 7,
+	},
+	"login_link": loginLinkData{
+		Link:             "https://travelplanner.test/auth/login/verify?token=abc",
+		ExpiresInMinutes: 15,
+	},
+	"trip_cancelled": tripCancelledData{
+		Trip: sampleTrip,
+	},
+	"trip_updated": tripUpdatedData{
+		Trip: sampleTrip,
+		Changes: []tripdiff.Change{
+			{Field: tripdiff.FieldDestination, Old: "Rio de Janeiro, Brazil", New: "Florianópolis, Brazil"},
+			{Field: tripdiff.FieldStartsAt, OldDate: time.Date(2024, time.July, 9, 0, 0, 0, 0, time.UTC), NewDate: sampleTrip.StartsAt},
+		},
+		UnsubscribeLink: "https://travelplanner.test/participants/2/unsubscribe?token=<abc>&x=1",
+	},
+}
+
+func TestTemplatesGolden(t *testing.T) {
+	for _, locale := range locales {
+		for _, name := range templateNames {
+			t.Run(locale+"/"+name, func(t *testing.T) {
+				data, ok := sampleData[name]
+				if !ok {
+					t.Fatalf("no sample data for template %s", name)
+				}
+
+				r, err := templates.render(name, locale, data)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				golden := filepath.Join("testdata", "golden", locale, name)
+				compareGolden(t, golden+".txt", "Subject: "+r.subject+"\n\n"+r.text)
+				compareGolden(t, golden+".html", r.html)
+			})
+		}
+	}
+}
+
+func compareGolden(t *testing.T, path string, got string) {
+	t.Helper()
+
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(path, []byte(got), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v, run go test with -update to create it", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("%s differs from the rendered output, run go test with -update if the change is intended\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestTemplatesTripChanges(t *testing.T) {
+	data := tripUpdatedData{
+		Trip: sampleTrip,
+		Changes: []tripdiff.Change{
+			{Field: tripdiff.FieldDestination, Old: "Lisbon", New: "Porto"},
+			{Field: tripdiff.FieldStartsAt, OldDate: time.Date(2024, time.July, 9, 0, 0, 0, 0, time.UTC), NewDate: sampleTrip.StartsAt},
+			{Field: tripdiff.FieldEndsAt, OldDate: time.Date(2024, time.July, 20, 0, 0, 0, 0, time.UTC), NewDate: sampleTrip.EndsAt},
+		},
+	}
+
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		{"en", []string{
+			"Destination: Lisbon -> Porto",
+			"Starts At: July 9, 2024 -> July 10, 2024",
+			"Ends At: July 20, 2024 -> July 17, 2024",
+		}},
+		{"pt-BR", []string{
+			"Destino: Lisbon -> Porto",
+			"Início: 09/07/2024 -> 10/07/2024",
+			"Fim: 20/07/2024 -> 17/07/2024",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			r, err := templates.render("trip_updated", tt.locale, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, line := range tt.want {
+				if !strings.Contains(r.text, line+"\n") {
+					t.Errorf("text does not list %q:\n%s", line, r.text)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplatesLocale(t *testing.T) {
+	tests := []struct {
+		preference string
+		want       string
+	}{
+		{"", "en"},
+		{"en", "en"},
+		{"en-GB", "en"},
+		{"pt-BR", "pt-BR"},
+		{"pt", "pt-BR"},
+		{"pt-PT", "pt-BR"},
+		{"ja", "en"},
+		{"not a tag", "en"},
+	}
+
+	for _, tt := range tests {
+		got := templates.locale(tt.preference)
+		if got != tt.want {
+			t.Errorf("locale(%q) = %q, want %q", tt.preference, got, tt.want)
+		}
+	}
+}
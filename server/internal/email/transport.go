@@ -0,0 +1,175 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wneessen/go-mail"
+)
+
+// Transport delivers finished messages. Send returns an error for each
+// message, nil for those delivered.
+type Transport interface {
+	Send(ctx context.Context, msgs ...*mail.Msg) []error
+}
+
+// SMTPTransport sends messages to an SMTP server, one connection per Send.
+// Sends run concurrently, each with a client of its own, as a client holds a
+// single connection and is not safe for concurrent use.
+type SMTPTransport struct {
+	host string
+	opts []mail.Option
+}
+
+// NewSMTPTransport returns a transport to the SMTP server in conf.
+func NewSMTPTransport(conf Config) (*SMTPTransport, error) {
+	opts := []mail.Option{
+		mail.WithPort(conf.Port),
+		mail.WithTLSPolicy(conf.TLSPolicy),
+	}
+	if conf.ImplicitTLS {
+		opts = append(opts, mail.WithSSL())
+	}
+	if conf.Username != "" {
+		opts = append(opts,
+			mail.WithSMTPAuth(conf.Auth),
+			mail.WithUsername(conf.Username),
+			mail.WithPassword(conf.Password),
+		)
+	}
+
+	// Creating a client checks the options, so a bad configuration fails at
+	// startup rather than on the first send.
+	_, err := mail.NewClient(conf.Host, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("Email: failed to create SMTP client: %w", err)
+	}
+
+	return &SMTPTransport{conf.Host, opts}, nil
+}
+
+func (t *SMTPTransport) Send(ctx context.Context, msgs ...*mail.Msg) []error {
+	errs := make([]error, len(msgs))
+
+	client, err := mail.NewClient(t.host, t.opts...)
+	if err != nil {
+		return fillErrors(errs, fmt.Errorf("Email: failed to create SMTP client: %w", err))
+	}
+
+	err = client.DialWithContext(ctx)
+	if err != nil {
+		return fillErrors(errs, fmt.Errorf("Email: failed to dial SMTP server: %w", err))
+	}
+	// Every message was handed over or failed by the time closing does, so
+	// its error changes nothing.
+	defer client.Close()
+
+	err = client.Send(msgs...)
+	if err == nil {
+		return errs
+	}
+
+	failed := false
+	for i, msg := range msgs {
+		if sendFailed(msg) {
+			errs[i] = msg.SendError()
+			failed = true
+		}
+	}
+
+	// The connection was lost before any message was tried.
+	if !failed {
+		fillErrors(errs, err)
+	}
+
+	return errs
+}
+
+// sendFailed reports whether the server did not take msg. go-mail marks a
+// message delivered before the server answers the end of its data, so that is
+// decided by what failed instead. Resetting the connection or checking it is
+// still up comes after the server took the message.
+func sendFailed(msg *mail.Msg) bool {
+	var sendErr *mail.SendError
+	if !errors.As(msg.SendError(), &sendErr) {
+		return false
+	}
+
+	return sendErr.Reason != mail.ErrSMTPReset && sendErr.Reason != mail.ErrConnCheck
+}
+
+// FileTransport writes each message to its own .eml file in a directory, for
+// development and tests.
+type FileTransport struct {
+	dir string
+}
+
+// NewFileTransport returns a transport writing to dir, which it creates if
+// needed.
+func NewFileTransport(dir string) (*FileTransport, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("Email: failed to create mail directory: %w", err)
+	}
+
+	return &FileTransport{dir}, nil
+}
+
+func (t *FileTransport) Send(_ context.Context, msgs ...*mail.Msg) []error {
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		// Names sort in the order the messages were sent.
+		name := time.Now().UTC().Format("20060102T150405.000000000") + "-" + uuid.NewString() + ".eml"
+
+		err := msg.WriteToFile(filepath.Join(t.dir, name))
+		if err != nil {
+			errs[i] = fmt.Errorf("Email: failed to write message to file: %w", err)
+		}
+	}
+
+	return errs
+}
+
+// Recorder keeps the messages sent through it in memory, for tests.
+type Recorder struct {
+	mu       sync.Mutex
+	messages []*mail.Msg
+}
+
+func (r *Recorder) Send(_ context.Context, msgs ...*mail.Msg) []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = append(r.messages, msgs...)
+	return make([]error, len(msgs))
+}
+
+// Messages returns the messages sent so far, oldest first.
+func (r *Recorder) Messages() []*mail.Msg {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*mail.Msg(nil), r.messages...)
+}
+
+// Reset forgets the messages sent so far.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = nil
+}
+
+func fillErrors(errs []error, err error) []error {
+	for i := range errs {
+		errs[i] = err
+	}
+
+	return errs
+}
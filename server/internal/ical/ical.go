@@ -0,0 +1,174 @@
+// Package ical writes trips as RFC 5545 iCalendar files, for e-mail
+// attachments and the calendar feeds participants subscribe to.
+package ical
+
+import (
+	"bytes"
+	"server/internal/pgstore"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of the files Encode writes.
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	prodID = "-//Travel Planner//Trip Calendar//EN"
+	// uidDomain makes the UIDs of events, the IDs of the rows they are for,
+	// unique among those of other calendars.
+	uidDomain = "travelplanner"
+	// refreshInterval is how often subscribed calendar apps are asked to
+	// fetch the feed again.
+	refreshInterval = "PT1H"
+)
+
+// Calendar is a VCALENDAR of events.
+type Calendar struct {
+	Name string
+	// Method is the iTIP method, PUBLISH for attachments, or empty for feeds.
+	Method string
+	Events []Event
+}
+
+// Event is a VEVENT. Events spanning whole days set AllDay, their Start and
+// End being the first and last day, the time of day ignored.
+type Event struct {
+	UID       string
+	Summary   string
+	Location  string
+	Start     time.Time
+	End       time.Time
+	AllDay    bool
+	Cancelled bool
+	// Sequence counts the revisions of the event, so calendar apps replace
+	// what they had.
+	Sequence int
+}
+
+// TripCalendar is the calendar of the trip, an all-day event spanning the
+// trip days in its own timezone and an event for each activity. Activities
+// have no duration, so their events end as they start.
+func TripCalendar(trip pgstore.GetTripRow, activities []pgstore.Activity) Calendar {
+	loc := pgstore.Location(trip.Timezone)
+
+	cancelled := trip.Status == "cancelled"
+	cal := Calendar{
+		Name: trip.Destination,
+		Events: []Event{{
+			UID:       trip.ID.String() + "@" + uidDomain,
+			Summary:   "Trip to " + trip.Destination,
+			Location:  trip.Destination,
+			Start:     trip.StartsAt.Time.In(loc),
+			End:       trip.EndsAt.Time.In(loc),
+			AllDay:    true,
+			Cancelled: cancelled,
+			Sequence:  int(trip.Version) - 1,
+		}},
+	}
+
+	for _, activity := range activities {
+		cal.Events = append(cal.Events, Event{
+			UID:       activity.ID.String() + "@" + uidDomain,
+			Summary:   activity.Title,
+			Location:  trip.Destination,
+			Start:     activity.OccursAt.Time,
+			End:       activity.OccursAt.Time,
+			Cancelled: cancelled,
+			Sequence:  int(activity.Version) - 1,
+		})
+	}
+
+	return cal
+}
+
+// Encode returns the calendar as an iCalendar file, stamped with now.
+func (c Calendar) Encode(now time.Time) []byte {
+	var b bytes.Buffer
+	line := func(name, value string) {
+		writeLine(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", prodID)
+	line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		line("METHOD", c.Method)
+	}
+	line("X-WR-CALNAME", escapeText(c.Name))
+	line("REFRESH-INTERVAL;VALUE=DURATION", refreshInterval)
+	line("X-PUBLISHED-TTL", refreshInterval)
+
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", formatDateTime(now))
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE", formatDate(e.Start))
+			// The end of all-day events is the day after the last one.
+			line("DTEND;VALUE=DATE", formatDate(e.End.AddDate(0, 0, 1)))
+		} else {
+			line("DTSTART", formatDateTime(e.Start))
+			line("DTEND", formatDateTime(e.End))
+		}
+		line("SUMMARY", escapeText(e.Summary))
+		if e.Location != "" {
+			line("LOCATION", escapeText(e.Location))
+		}
+		line("SEQUENCE", strconv.Itoa(max(e.Sequence, 0)))
+		if e.Cancelled {
+			line("STATUS", "CANCELLED")
+		} else {
+			line("STATUS", "CONFIRMED")
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return b.Bytes()
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeText escapes a TEXT value, RFC 5545 section 3.3.11.
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeLine writes a content line ended by CRLF, folded so no line is longer
+// than 75 octets without splitting a UTF-8 sequence, RFC 5545 section 3.1.
+func writeLine(b *bytes.Buffer, s string) {
+	const limit = 75
+
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with the space.
+		width = limit - 1
+	}
+
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,78 @@
+package ical
+
+import (
+	"server/internal/pgstore"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestTripCalendar(t *testing.T) {
+	trip := pgstore.GetTripRow{
+		ID:          uuid.MustParse("6f1c8a2e-4b7d-4c1e-9a3f-2d5e8b7c6a10"),
+		Destination: "Florianópolis, Brazil; Santa Catarina",
+		Status:      "confirmed",
+		// Midnight in São Paulo is 03:00 UTC, the days must be São Paulo's.
+		StartsAt: pgtype.Timestamptz{Valid: true, Time: time.Date(2024, time.July, 10, 3, 0, 0, 0, time.UTC)},
+		EndsAt:   pgtype.Timestamptz{Valid: true, Time: time.Date(2024, time.July, 17, 3, 0, 0, 0, time.UTC)},
+		Timezone: "America/Sao_Paulo",
+		Version:  3,
+	}
+	activities := []pgstore.Activity{{
+		ID:       uuid.MustParse("0b9e4f6d-8c2a-4e5b-b1d7-3f6a9c8e2d40"),
+		Title:    strings.Repeat("Surf lesson at Praia Mole, ", 4),
+		OccursAt: pgtype.Timestamptz{Valid: true, Time: time.Date(2024, time.July, 11, 12, 30, 0, 0, time.UTC)},
+		Version:  1,
+	}}
+
+	got := string(TripCalendar(trip, activities).Encode(time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:6f1c8a2e-4b7d-4c1e-9a3f-2d5e8b7c6a10@travelplanner\r\n",
+		"DTSTAMP:20240701T000000Z\r\n",
+		"DTSTART;VALUE=DATE:20240710\r\n",
+		"DTEND;VALUE=DATE:20240718\r\n",
+		`SUMMARY:Trip to Florianópolis\, Brazil\; Santa Catarina` + "\r\n",
+		"SEQUENCE:2\r\n",
+		"DTSTART:20240711T123000Z\r\n",
+		"STATUS:CONFIRMED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("calendar has no line %q:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(strings.ReplaceAll(got, "\r\n", ""), "\n") {
+		t.Error("calendar has lines not ended by CRLF")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n")
+	for _, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("line is %d octets long: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a UTF-8 sequence: %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(got, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat(`Surf lesson at Praia Mole\, `, 4)+"\r\n") {
+		t.Errorf("folded activity summary does not unfold back:\n%s", got)
+	}
+}
+
+func TestTripCalendarCancelled(t *testing.T) {
+	trip := pgstore.GetTripRow{Destination: "Lisbon", Status: "cancelled", Timezone: "Europe/Lisbon", Version: 1}
+
+	got := string(TripCalendar(trip, nil).Encode(time.Now()))
+	if !strings.Contains(got, "STATUS:CANCELLED\r\n") {
+		t.Errorf("cancelled trip is not a cancelled event:\n%s", got)
+	}
+}
@@ -0,0 +1,186 @@
+// Package jobs runs background work on a bounded pool of workers that can be
+// drained on shutdown.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrPoolClosed is returned by Submit once Shutdown was called.
+var ErrPoolClosed = errors.New("jobs: pool is shut down")
+
+// Job is a unit of background work. Its context carries the values of the
+// context it was submitted with, but is only cancelled when the pool gives
+// up draining on shutdown.
+type Job func(ctx context.Context) error
+
+type task struct {
+	name string
+	ctx  context.Context
+	job  Job
+}
+
+// Stats is a snapshot of a pool. Queued and Running are the jobs waiting and
+// running right now, the others count jobs since the pool started.
+type Stats struct {
+	Submitted int64 `json:"submitted"`
+	Queued    int64 `json:"queued"`
+	Running   int64 `json:"running"`
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+}
+
+// Pool runs submitted jobs on a fixed number of workers. Jobs wait in a
+// bounded queue while every worker is busy, and Submit blocks once it is
+// full.
+type Pool struct {
+	logger *zap.Logger
+	tasks  chan task
+	wg     sync.WaitGroup
+
+	// mu guards closed against jobs being submitted while tasks is closed.
+	mu     sync.RWMutex
+	closed bool
+
+	// ctx is the parent of every job context, cancelled when draining
+	// takes too long.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	submitted atomic.Int64
+	running   atomic.Int64
+	succeeded atomic.Int64
+	failed    atomic.Int64
+}
+
+// NewPool starts concurrency workers, and queues up to queueSize jobs while
+// they are busy. concurrency is at least 1.
+func NewPool(logger *zap.Logger, concurrency int, queueSize int) *Pool {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		logger: logger.Named("jobs"),
+		tasks:  make(chan task, queueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	p.wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+// Submit queues the job, waiting for room in the queue until ctx is done.
+// Cancelling ctx later does not cancel the job.
+func (p *Pool) Submit(ctx context.Context, name string, job Job) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	select {
+	case p.tasks <- task{name, context.WithoutCancel(ctx), job}:
+		p.submitted.Add(1)
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("jobs: failed to submit %s: %w", name, ctx.Err())
+	}
+}
+
+// Shutdown stops accepting jobs and waits for the queued and running ones to
+// finish. When ctx is done first it cancels the contexts of the jobs still
+// running and returns ctx.Err(), wrapped, without waiting for them.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return fmt.Errorf("jobs: failed to drain pool: %w", ctx.Err())
+	}
+}
+
+// Stats returns the current counters of the pool.
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Submitted: p.submitted.Load(),
+		Queued:    int64(len(p.tasks)),
+		Running:   p.running.Load(),
+		Succeeded: p.succeeded.Load(),
+		Failed:    p.failed.Load(),
+	}
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for t := range p.tasks {
+		p.run(t)
+	}
+}
+
+func (p *Pool) run(t task) {
+	ctx, cancel := context.WithCancel(t.ctx)
+	stop := context.AfterFunc(p.ctx, cancel)
+	defer stop()
+	defer cancel()
+
+	p.running.Add(1)
+	defer p.running.Add(-1)
+
+	start := time.Now()
+	err := safeRun(ctx, t.job)
+	if err != nil {
+		p.failed.Add(1)
+		p.logger.Error(
+			"Job failed",
+			zap.Error(err),
+			zap.String("job", t.name),
+			zap.Duration("duration", time.Since(start)),
+		)
+		return
+	}
+
+	p.succeeded.Add(1)
+}
+
+// safeRun runs the job, turning a panic into an error so one bad job does
+// not take the worker, or the process, down with it.
+func safeRun(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("jobs: job panicked: %v", r)
+		}
+	}()
+
+	return job(ctx)
+}
@@ -0,0 +1,150 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func succeed(context.Context) error { return nil }
+
+func fail(context.Context) error { return errors.New("failed") }
+
+func panicking(context.Context) error { panic("boom") }
+
+func slow(ctx context.Context) error {
+	time.Sleep(5 * time.Millisecond)
+	return nil
+}
+
+// blocking runs until the pool cancels it.
+func blocking(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestPoolShutdown(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		jobs        []Job
+		timeout     time.Duration
+		wantErr     error
+		want        Stats
+	}{
+		{
+			name:        "no jobs",
+			concurrency: 2,
+			timeout:     time.Second,
+			want:        Stats{},
+		},
+		{
+			name:        "drains the queue",
+			concurrency: 1,
+			jobs:        []Job{slow, slow, slow, slow, slow},
+			timeout:     time.Second,
+			want:        Stats{Submitted: 5, Succeeded: 5},
+		},
+		{
+			name:        "counts failures and panics",
+			concurrency: 2,
+			jobs:        []Job{succeed, fail, panicking, succeed},
+			timeout:     time.Second,
+			want:        Stats{Submitted: 4, Succeeded: 2, Failed: 2},
+		},
+		{
+			name:        "cancels running jobs when draining times out",
+			concurrency: 2,
+			jobs:        []Job{blocking, blocking},
+			timeout:     10 * time.Millisecond,
+			wantErr:     context.DeadlineExceeded,
+			want:        Stats{Submitted: 2, Failed: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPool(zap.NewNop(), tt.concurrency, len(tt.jobs))
+			for i, job := range tt.jobs {
+				err := p.Submit(context.Background(), "test", job)
+				if err != nil {
+					t.Fatalf("Submit job %d: %v", i, err)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			err := p.Shutdown(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Shutdown returned %v, want %v", err, tt.wantErr)
+			}
+
+			// Jobs cancelled by a timed out drain finish after Shutdown returns.
+			p.wg.Wait()
+
+			if got := p.Stats(); got != tt.want {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.want)
+			}
+
+			err = p.Submit(context.Background(), "late", succeed)
+			if !errors.Is(err, ErrPoolClosed) {
+				t.Errorf("Submit after Shutdown returned %v, want ErrPoolClosed", err)
+			}
+		})
+	}
+}
+
+func TestPoolSubmitContext(t *testing.T) {
+	p := NewPool(zap.NewNop(), 1, 0)
+	defer p.Shutdown(context.Background())
+
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+
+	started, release := make(chan struct{}), make(chan struct{})
+	result := make(chan error, 1)
+	err := p.Submit(ctx, "test", func(ctx context.Context) error {
+		close(started)
+		<-release
+		if ctx.Value(key{}) != "value" {
+			result <- errors.New("job context lost the submitter's values")
+		} else {
+			result <- ctx.Err()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	// Cancelling the submitter does not cancel a job already submitted.
+	cancel()
+	close(release)
+	if err := <-result; err != nil {
+		t.Error(err)
+	}
+
+	// With the only worker busy and no queue, Submit waits until its context
+	// is done.
+	block := make(chan struct{})
+	defer close(block)
+	err = p.Submit(context.Background(), "busy", func(context.Context) error {
+		<-block
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = p.Submit(ctx, "waiting", succeed)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Submit to a full pool returned %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,81 @@
+package pgstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TripIncludes selects the rows related to a trip GetTripAggregate loads
+// along with it.
+type TripIncludes struct {
+	Participants bool
+	Activities   bool
+	Links        bool
+}
+
+// TripAggregate is a trip and the related rows asked for in TripIncludes.
+// The slices of rows not asked for are nil.
+type TripAggregate struct {
+	Trip         GetTripRow
+	Participants []GetParticipantsRow
+	Activities   []Activity
+	Links        []Link
+}
+
+// GetTripAggregate loads the trip and the related rows include asks for in a
+// single round trip. It returns pgx.ErrNoRows, wrapped, when the trip does
+// not exist.
+func (q *Queries) GetTripAggregate(ctx context.Context, pool *pgxpool.Pool, tripID uuid.UUID, include TripIncludes) (TripAggregate, error) {
+	var agg TripAggregate
+	batch := &pgx.Batch{}
+
+	batch.Queue(getTrip, tripID).QueryRow(func(row pgx.Row) error {
+		return row.Scan(
+			&agg.Trip.ID,
+			&agg.Trip.Destination,
+			&agg.Trip.OwnerID,
+			&agg.Trip.OwnerEmail,
+			&agg.Trip.OwnerName,
+			&agg.Trip.Status,
+			&agg.Trip.StartsAt,
+			&agg.Trip.EndsAt,
+			&agg.Trip.Timezone,
+			&agg.Trip.Version,
+		)
+	})
+
+	if include.Participants {
+		batch.Queue(getParticipants, tripID).Query(func(rows pgx.Rows) error {
+			var err error
+			agg.Participants, err = pgx.CollectRows(rows, pgx.RowToStructByPos[GetParticipantsRow])
+			return err
+		})
+	}
+
+	if include.Activities {
+		batch.Queue(getTripActivities, tripID).Query(func(rows pgx.Rows) error {
+			var err error
+			agg.Activities, err = pgx.CollectRows(rows, pgx.RowToStructByPos[Activity])
+			return err
+		})
+	}
+
+	if include.Links {
+		batch.Queue(getTripLinks, tripID).Query(func(rows pgx.Rows) error {
+			var err error
+			agg.Links, err = pgx.CollectRows(rows, pgx.RowToStructByPos[Link])
+			return err
+		})
+	}
+
+	err := pool.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return TripAggregate{}, fmt.Errorf("pgstore: failed to run batch for GetTripAggregate: %w", err)
+	}
+
+	return agg, nil
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS login_tokens (
+    "id"            uuid            PRIMARY KEY NOT NULL    DEFAULT gen_random_uuid(),
+    "email"         VARCHAR(255)                NOT NULL,
+    "expires_at"    TIMESTAMP                   NOT NULL,
+    "consumed_at"   TIMESTAMP
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS login_tokens;
\ No newline at end of file
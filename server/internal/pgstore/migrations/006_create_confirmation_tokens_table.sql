@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS confirmation_tokens (
+    "id"                uuid            PRIMARY KEY NOT NULL    DEFAULT gen_random_uuid(),
+    "trip_id"           uuid                        NOT NULL,
+    "participant_id"    uuid,
+    "expires_at"        TIMESTAMP                   NOT NULL,
+    "consumed_at"       TIMESTAMP,
+
+    FOREIGN KEY (trip_id) REFERENCES trips(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    FOREIGN KEY (participant_id) REFERENCES participants(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS confirmation_tokens;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE participants
+    ADD COLUMN "role" VARCHAR(20) NOT NULL DEFAULT 'participant'
+        CHECK ("role" IN ('co_organizer', 'participant', 'viewer'));
+
+---- create above / drop below ----
+
+ALTER TABLE participants DROP COLUMN IF EXISTS "role";
\ No newline at end of file
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS users (
+    "id"            uuid            PRIMARY KEY NOT NULL    DEFAULT gen_random_uuid(),
+    "email"         VARCHAR(255)    UNIQUE      NOT NULL,
+    "name"          VARCHAR(255),
+    "locale"        VARCHAR(35),
+    "timezone"      VARCHAR(64)
+);
+
+INSERT INTO users ( "email", "name" )
+SELECT DISTINCT ON (LOWER(owner_email)) LOWER(owner_email), owner_name
+FROM trips
+ORDER BY LOWER(owner_email)
+ON CONFLICT ("email") DO NOTHING;
+
+INSERT INTO users ( "email" )
+SELECT DISTINCT LOWER(email)
+FROM participants
+ON CONFLICT ("email") DO NOTHING;
+
+ALTER TABLE trips ADD COLUMN "owner_id" uuid REFERENCES users(id) ON UPDATE CASCADE;
+UPDATE trips SET "owner_id" = users.id FROM users WHERE users.email = LOWER(trips.owner_email);
+ALTER TABLE trips ALTER COLUMN "owner_id" SET NOT NULL;
+ALTER TABLE trips DROP COLUMN "owner_email", DROP COLUMN "owner_name";
+
+ALTER TABLE participants ADD COLUMN "user_id" uuid REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE;
+UPDATE participants SET "user_id" = users.id FROM users WHERE users.email = LOWER(participants.email);
+ALTER TABLE participants ALTER COLUMN "user_id" SET NOT NULL;
+ALTER TABLE participants DROP COLUMN "email";
+
+---- create above / drop below ----
+
+ALTER TABLE participants ADD COLUMN "email" VARCHAR(255);
+UPDATE participants SET "email" = users.email FROM users WHERE users.id = participants.user_id;
+ALTER TABLE participants ALTER COLUMN "email" SET NOT NULL;
+ALTER TABLE participants DROP COLUMN "user_id";
+
+ALTER TABLE trips ADD COLUMN "owner_email" VARCHAR(255), ADD COLUMN "owner_name" VARCHAR(255);
+UPDATE trips SET "owner_email" = users.email, "owner_name" = COALESCE(users.name, '') FROM users WHERE users.id = trips.owner_id;
+ALTER TABLE trips ALTER COLUMN "owner_email" SET NOT NULL, ALTER COLUMN "owner_name" SET NOT NULL;
+ALTER TABLE trips DROP COLUMN "owner_id";
+
+DROP TABLE IF EXISTS users;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS trips_owner_id_starts_at_idx ON trips ("owner_id", "starts_at");
+CREATE INDEX IF NOT EXISTS participants_user_id_idx ON participants ("user_id");
+
+---- create above / drop below ----
+
+DROP INDEX IF EXISTS participants_user_id_idx;
+DROP INDEX IF EXISTS trips_owner_id_starts_at_idx;
\ No newline at end of file
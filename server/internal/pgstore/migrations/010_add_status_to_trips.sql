@@ -0,0 +1,13 @@
+ALTER TABLE trips
+    ADD COLUMN "status" VARCHAR(20) NOT NULL DEFAULT 'draft'
+        CHECK ("status" IN ('draft', 'confirmed', 'cancelled', 'completed', 'archived')),
+    ADD COLUMN "deleted_at" TIMESTAMP;
+
+UPDATE trips SET "status" = 'confirmed' WHERE is_confirmed;
+ALTER TABLE trips DROP COLUMN "is_confirmed";
+
+---- create above / drop below ----
+
+ALTER TABLE trips ADD COLUMN "is_confirmed" BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE trips SET "is_confirmed" = TRUE WHERE status IN ('confirmed', 'completed');
+ALTER TABLE trips DROP COLUMN "status", DROP COLUMN "deleted_at";
\ No newline at end of file
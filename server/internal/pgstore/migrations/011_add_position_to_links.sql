@@ -0,0 +1,12 @@
+ALTER TABLE links ADD COLUMN "position" INTEGER NOT NULL DEFAULT 0;
+
+UPDATE links SET "position" = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY trip_id ORDER BY title, id) - 1 AS position
+    FROM links
+) AS ordered
+WHERE links.id = ordered.id;
+
+---- create above / drop below ----
+
+ALTER TABLE links DROP COLUMN IF EXISTS "position";
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE participants
+    ADD COLUMN "rsvp_status" VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK ("rsvp_status" IN ('pending', 'confirmed', 'declined')),
+    ADD COLUMN "invited_at" TIMESTAMP;
+
+UPDATE participants SET "rsvp_status" = 'confirmed' WHERE is_confirmed;
+ALTER TABLE participants DROP COLUMN "is_confirmed";
+
+---- create above / drop below ----
+
+ALTER TABLE participants ADD COLUMN "is_confirmed" BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE participants SET "is_confirmed" = TRUE WHERE rsvp_status = 'confirmed';
+ALTER TABLE participants DROP COLUMN "rsvp_status", DROP COLUMN "invited_at";
\ No newline at end of file
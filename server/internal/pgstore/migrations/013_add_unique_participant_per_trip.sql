@@ -0,0 +1,50 @@
+-- Migration 008 only lowercased e-mails, so users whose addresses differ by
+-- surrounding spaces are the same person. Each is merged into the one whose
+-- address is already normalized, or the first.
+CREATE TEMPORARY TABLE user_merges AS
+SELECT
+    id,
+    FIRST_VALUE(id) OVER (
+        PARTITION BY LOWER(TRIM(email))
+        ORDER BY CASE WHEN email = LOWER(TRIM(email)) THEN 0 ELSE 1 END, id
+    ) AS merged_id
+FROM users;
+
+UPDATE trips SET "owner_id" = user_merges.merged_id
+FROM user_merges
+WHERE trips.owner_id = user_merges.id AND user_merges.id <> user_merges.merged_id;
+
+UPDATE participants SET "user_id" = user_merges.merged_id
+FROM user_merges
+WHERE participants.user_id = user_merges.id AND user_merges.id <> user_merges.merged_id;
+
+DELETE FROM participants
+WHERE id IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (
+            PARTITION BY trip_id, user_id
+            ORDER BY CASE rsvp_status WHEN 'confirmed' THEN 0 WHEN 'declined' THEN 1 ELSE 2 END, id
+        ) AS duplicate
+        FROM participants
+    ) AS ranked
+    WHERE ranked.duplicate > 1
+);
+
+ALTER TABLE participants
+    ADD CONSTRAINT "participants_trip_id_user_id_key" UNIQUE ("trip_id", "user_id");
+
+DELETE FROM users
+USING user_merges
+WHERE users.id = user_merges.id AND user_merges.id <> user_merges.merged_id;
+
+DROP TABLE user_merges;
+
+UPDATE users SET "email" = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+
+ALTER TABLE users
+    ADD CONSTRAINT "users_email_normalized" CHECK ("email" = LOWER(TRIM("email")));
+
+---- create above / drop below ----
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS "users_email_normalized";
+ALTER TABLE participants DROP CONSTRAINT IF EXISTS "participants_trip_id_user_id_key";
\ No newline at end of file
@@ -0,0 +1,49 @@
+ALTER TABLE trips
+    ALTER COLUMN "starts_at" TYPE TIMESTAMPTZ USING "starts_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "ends_at" TYPE TIMESTAMPTZ USING "ends_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "deleted_at" TYPE TIMESTAMPTZ USING "deleted_at" AT TIME ZONE 'UTC',
+    ADD COLUMN "timezone" VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+UPDATE trips SET "timezone" = users.timezone
+FROM users
+WHERE
+    users.id = trips.owner_id
+    AND users.timezone IN (SELECT name FROM pg_timezone_names);
+
+ALTER TABLE activities
+    ALTER COLUMN "occurs_at" TYPE TIMESTAMPTZ USING "occurs_at" AT TIME ZONE 'UTC',
+    ADD COLUMN "timezone" VARCHAR(64);
+
+ALTER TABLE participants
+    ALTER COLUMN "invited_at" TYPE TIMESTAMPTZ USING "invited_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE login_tokens
+    ALTER COLUMN "expires_at" TYPE TIMESTAMPTZ USING "expires_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "consumed_at" TYPE TIMESTAMPTZ USING "consumed_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE confirmation_tokens
+    ALTER COLUMN "expires_at" TYPE TIMESTAMPTZ USING "expires_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "consumed_at" TYPE TIMESTAMPTZ USING "consumed_at" AT TIME ZONE 'UTC';
+
+---- create above / drop below ----
+
+ALTER TABLE confirmation_tokens
+    ALTER COLUMN "expires_at" TYPE TIMESTAMP USING "expires_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "consumed_at" TYPE TIMESTAMP USING "consumed_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE login_tokens
+    ALTER COLUMN "expires_at" TYPE TIMESTAMP USING "expires_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "consumed_at" TYPE TIMESTAMP USING "consumed_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE participants
+    ALTER COLUMN "invited_at" TYPE TIMESTAMP USING "invited_at" AT TIME ZONE 'UTC';
+
+ALTER TABLE activities
+    ALTER COLUMN "occurs_at" TYPE TIMESTAMP USING "occurs_at" AT TIME ZONE 'UTC',
+    DROP COLUMN "timezone";
+
+ALTER TABLE trips
+    ALTER COLUMN "starts_at" TYPE TIMESTAMP USING "starts_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "ends_at" TYPE TIMESTAMP USING "ends_at" AT TIME ZONE 'UTC',
+    ALTER COLUMN "deleted_at" TYPE TIMESTAMP USING "deleted_at" AT TIME ZONE 'UTC',
+    DROP COLUMN "timezone";
\ No newline at end of file
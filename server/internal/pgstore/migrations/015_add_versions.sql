@@ -0,0 +1,13 @@
+ALTER TABLE trips ADD COLUMN "version" INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE activities ADD COLUMN "version" INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE links ADD COLUMN "version" INTEGER NOT NULL DEFAULT 1;
+
+---- create above / drop below ----
+
+ALTER TABLE links DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE activities DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE trips DROP COLUMN IF EXISTS "version";
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE participants ADD COLUMN "notify_changes" BOOLEAN NOT NULL DEFAULT TRUE;
+
+---- create above / drop below ----
+
+ALTER TABLE participants DROP COLUMN IF EXISTS "notify_changes";
\ No newline at end of file
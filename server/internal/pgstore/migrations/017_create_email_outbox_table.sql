@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS email_outbox (
+    "id"                uuid            PRIMARY KEY NOT NULL    DEFAULT gen_random_uuid(),
+    "kind"              VARCHAR(32)                 NOT NULL,
+    "trip_id"           uuid,
+    "participant_id"    uuid,
+    "recipient"         VARCHAR(255)                NOT NULL,
+    "payload"           JSONB                       NOT NULL    DEFAULT '{}',
+    "status"            VARCHAR(16)                 NOT NULL    DEFAULT 'pending',
+    "attempts"          INTEGER                     NOT NULL    DEFAULT 0,
+    "last_error"        TEXT,
+    "next_attempt_at"   TIMESTAMPTZ                 NOT NULL    DEFAULT NOW(),
+    "created_at"        TIMESTAMPTZ                 NOT NULL    DEFAULT NOW(),
+    "sent_at"           TIMESTAMPTZ,
+
+    FOREIGN KEY (trip_id) REFERENCES trips(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    FOREIGN KEY (participant_id) REFERENCES participants(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS email_outbox_pending_idx ON email_outbox ("next_attempt_at") WHERE "status" = 'pending';
+CREATE INDEX IF NOT EXISTS email_outbox_participant_idx ON email_outbox ("participant_id");
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS email_outbox;
\ No newline at end of file
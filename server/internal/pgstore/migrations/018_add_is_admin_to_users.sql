@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN "is_admin" BOOLEAN NOT NULL DEFAULT FALSE;
+
+---- create above / drop below ----
+
+ALTER TABLE users DROP COLUMN IF EXISTS "is_admin";
\ No newline at end of file
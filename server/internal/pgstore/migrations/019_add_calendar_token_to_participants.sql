@@ -0,0 +1,5 @@
+ALTER TABLE participants ADD COLUMN "calendar_token" uuid NOT NULL UNIQUE DEFAULT gen_random_uuid();
+
+---- create above / drop below ----
+
+ALTER TABLE participants DROP COLUMN IF EXISTS "calendar_token";
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS email_change_tokens (
+    "id"            uuid            PRIMARY KEY NOT NULL    DEFAULT gen_random_uuid(),
+    "user_id"       uuid                        NOT NULL,
+    "email"         VARCHAR(255)                NOT NULL,
+    "expires_at"    TIMESTAMPTZ                 NOT NULL,
+    "consumed_at"   TIMESTAMPTZ,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+---- create above / drop below ----
+
+DROP TABLE IF EXISTS email_change_tokens;
\ No newline at end of file
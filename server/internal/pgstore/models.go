@@ -13,29 +13,86 @@ type Activity struct {
 	ID       uuid.UUID
 	TripID   uuid.UUID
 	Title    string
-	OccursAt pgtype.Timestamp
+	OccursAt pgtype.Timestamptz
+	Timezone pgtype.Text
+	Version  int32
+}
+
+type ConfirmationToken struct {
+	ID            uuid.UUID
+	TripID        uuid.UUID
+	ParticipantID pgtype.UUID
+	ExpiresAt     pgtype.Timestamptz
+	ConsumedAt    pgtype.Timestamptz
+}
+
+type EmailChangeToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Email      string
+	ExpiresAt  pgtype.Timestamptz
+	ConsumedAt pgtype.Timestamptz
+}
+
+type EmailOutbox struct {
+	ID            uuid.UUID
+	Kind          string
+	TripID        pgtype.UUID
+	ParticipantID pgtype.UUID
+	Recipient     string
+	Payload       []byte
+	Status        string
+	Attempts      int32
+	LastError     pgtype.Text
+	NextAttemptAt pgtype.Timestamptz
+	CreatedAt     pgtype.Timestamptz
+	SentAt        pgtype.Timestamptz
 }
 
 type Link struct {
-	ID     uuid.UUID
-	TripID uuid.UUID
-	Title  string
-	Url    string
+	ID       uuid.UUID
+	TripID   uuid.UUID
+	Title    string
+	Url      string
+	Position int32
+	Version  int32
+}
+
+type LoginToken struct {
+	ID         uuid.UUID
+	Email      string
+	ExpiresAt  pgtype.Timestamptz
+	ConsumedAt pgtype.Timestamptz
 }
 
 type Participant struct {
-	ID          uuid.UUID
-	TripID      uuid.UUID
-	Email       string
-	IsConfirmed bool
+	ID            uuid.UUID
+	TripID        uuid.UUID
+	Role          string
+	UserID        uuid.UUID
+	RsvpStatus    string
+	InvitedAt     pgtype.Timestamptz
+	NotifyChanges bool
+	CalendarToken uuid.UUID
 }
 
 type Trip struct {
 	ID          uuid.UUID
 	Destination string
-	OwnerEmail  string
-	OwnerName   string
-	IsConfirmed bool
-	StartsAt    pgtype.Timestamp
-	EndsAt      pgtype.Timestamp
+	StartsAt    pgtype.Timestamptz
+	EndsAt      pgtype.Timestamptz
+	OwnerID     uuid.UUID
+	Status      string
+	DeletedAt   pgtype.Timestamptz
+	Timezone    string
+	Version     int32
+}
+
+type User struct {
+	ID       uuid.UUID
+	Email    string
+	Name     pgtype.Text
+	Locale   pgtype.Text
+	Timezone pgtype.Text
+	IsAdmin  bool
 }
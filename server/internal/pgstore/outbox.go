@@ -0,0 +1,39 @@
+package pgstore
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Kinds of e-mail queued in the outbox. The dispatcher renders each one when
+// it sends it, so the links in it are fresh even after a few retries.
+const (
+	EmailChangeEmail   = "change_email"
+	EmailConfirmTrip   = "confirm_trip"
+	EmailInvite        = "invite"
+	EmailLoginLink     = "login_link"
+	EmailTripCancelled = "trip_cancelled"
+	EmailTripUpdated   = "trip_updated"
+)
+
+// Delivery states of an e-mail in the outbox. Dead e-mails ran out of
+// attempts and are kept for inspection only.
+const (
+	EmailPending = "pending"
+	EmailSent    = "sent"
+	EmailDead    = "dead"
+)
+
+// enqueueParticipantEmail queues an e-mail of the given kind, which needs no
+// payload, to a participant of a trip.
+func (q *Queries) enqueueParticipantEmail(ctx context.Context, kind string, tripID uuid.UUID, participantID uuid.UUID, recipient string) error {
+	return q.EnqueueEmail(ctx, EnqueueEmailParams{
+		Kind:          kind,
+		TripID:        pgtype.UUID{Valid: true, Bytes: tripID},
+		ParticipantID: pgtype.UUID{Valid: true, Bytes: participantID},
+		Recipient:     recipient,
+		Payload:       []byte("{}"),
+	})
+}
@@ -12,32 +12,133 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const confirmParticipant = `-- name: ConfirmParticipant :exec
-UPDATE participants
-SET "is_confirmed" = true
-WHERE id = $1
+const claimDueEmails = `-- name: ClaimDueEmails :many
+UPDATE email_outbox
+SET
+    "attempts" = "attempts" + 1,
+    "next_attempt_at" = NOW() + INTERVAL '5 minutes'
+WHERE id IN (
+    SELECT id FROM email_outbox
+    WHERE
+        status = 'pending'
+        AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING
+    "id", "kind", "trip_id", "participant_id", "recipient", "payload", "status",
+    "attempts", "last_error", "next_attempt_at", "created_at", "sent_at"
 `
 
-func (q *Queries) ConfirmParticipant(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, confirmParticipant, id)
-	return err
+func (q *Queries) ClaimDueEmails(ctx context.Context, limit int32) ([]EmailOutbox, error) {
+	rows, err := q.db.Query(ctx, claimDueEmails, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EmailOutbox
+	for rows.Next() {
+		var i EmailOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.TripID,
+			&i.ParticipantID,
+			&i.Recipient,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const consumeConfirmationToken = `-- name: ConsumeConfirmationToken :execrows
+UPDATE confirmation_tokens
+SET "consumed_at" = NOW()
+WHERE
+    id = $1
+    AND consumed_at IS NULL
+`
+
+func (q *Queries) ConsumeConfirmationToken(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, consumeConfirmationToken, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const consumeEmailChangeToken = `-- name: ConsumeEmailChangeToken :one
+UPDATE email_change_tokens
+SET "consumed_at" = NOW()
+WHERE
+    id = $1
+    AND user_id = $2
+    AND consumed_at IS NULL
+RETURNING "email"
+`
+
+type ConsumeEmailChangeTokenParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) ConsumeEmailChangeToken(ctx context.Context, arg ConsumeEmailChangeTokenParams) (string, error) {
+	row := q.db.QueryRow(ctx, consumeEmailChangeToken, arg.ID, arg.UserID)
+	var email string
+	err := row.Scan(&email)
+	return email, err
+}
+
+const consumeLoginToken = `-- name: ConsumeLoginToken :execrows
+UPDATE login_tokens
+SET "consumed_at" = NOW()
+WHERE
+    id = $1
+    AND consumed_at IS NULL
+`
+
+func (q *Queries) ConsumeLoginToken(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, consumeLoginToken, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const createActivity = `-- name: CreateActivity :one
 INSERT INTO activities
-    ( "trip_id", "title", "occurs_at" ) VALUES
-    ( $1, $2, $3 )
+    ( "trip_id", "title", "occurs_at", "timezone" ) VALUES
+    ( $1, $2, $3, $4 )
 RETURNING "id"
 `
 
 type CreateActivityParams struct {
 	TripID   uuid.UUID
 	Title    string
-	OccursAt pgtype.Timestamp
+	OccursAt pgtype.Timestamptz
+	Timezone pgtype.Text
 }
 
 func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, createActivity, arg.TripID, arg.Title, arg.OccursAt)
+	row := q.db.QueryRow(ctx, createActivity,
+		arg.TripID,
+		arg.Title,
+		arg.OccursAt,
+		arg.Timezone,
+	)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
@@ -45,8 +146,8 @@ func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams)
 
 const createTripLink = `-- name: CreateTripLink :one
 INSERT INTO links
-    ( "trip_id", "title", "url" ) VALUES
-    ( $1, $2, $3 )
+    ( "trip_id", "title", "url", "position" ) VALUES
+    ( $1, $2, $3, (SELECT COALESCE(MAX(position) + 1, 0) FROM links WHERE trip_id = $1) )
 RETURNING "id"
 `
 
@@ -63,48 +164,307 @@ func (q *Queries) CreateTripLink(ctx context.Context, arg CreateTripLinkParams)
 	return id, err
 }
 
+const deleteActivities = `-- name: DeleteActivities :execrows
+DELETE FROM activities
+WHERE
+    trip_id = $1
+    AND id = ANY($2::uuid[])
+`
+
+type DeleteActivitiesParams struct {
+	TripID uuid.UUID
+	Ids    []uuid.UUID
+}
+
+func (q *Queries) DeleteActivities(ctx context.Context, arg DeleteActivitiesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteActivities, arg.TripID, arg.Ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteActivity = `-- name: DeleteActivity :execrows
+DELETE FROM activities
+WHERE
+    id = $1
+    AND trip_id = $2
+`
+
+type DeleteActivityParams struct {
+	ID     uuid.UUID
+	TripID uuid.UUID
+}
+
+func (q *Queries) DeleteActivity(ctx context.Context, arg DeleteActivityParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteActivity, arg.ID, arg.TripID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteLink = `-- name: DeleteLink :execrows
+DELETE FROM links
+WHERE
+    id = $1
+    AND trip_id = $2
+`
+
+type DeleteLinkParams struct {
+	ID     uuid.UUID
+	TripID uuid.UUID
+}
+
+func (q *Queries) DeleteLink(ctx context.Context, arg DeleteLinkParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteLink, arg.ID, arg.TripID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteParticipant = `-- name: DeleteParticipant :execrows
+DELETE FROM participants
+WHERE
+    id = $1
+    AND trip_id = $2
+`
+
+type DeleteParticipantParams struct {
+	ID     uuid.UUID
+	TripID uuid.UUID
+}
+
+func (q *Queries) DeleteParticipant(ctx context.Context, arg DeleteParticipantParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteParticipant, arg.ID, arg.TripID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTrip = `-- name: DeleteTrip :execrows
+UPDATE trips
+SET "deleted_at" = NOW()
+WHERE
+    id = $1
+    AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteTrip(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTrip, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const enqueueEmail = `-- name: EnqueueEmail :exec
+INSERT INTO email_outbox
+    ( "kind", "trip_id", "participant_id", "recipient", "payload" ) VALUES
+    ( $1, $2, $3, $4, $5 )
+`
+
+type EnqueueEmailParams struct {
+	Kind          string
+	TripID        pgtype.UUID
+	ParticipantID pgtype.UUID
+	Recipient     string
+	Payload       []byte
+}
+
+func (q *Queries) EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) error {
+	_, err := q.db.Exec(ctx, enqueueEmail,
+		arg.Kind,
+		arg.TripID,
+		arg.ParticipantID,
+		arg.Recipient,
+		arg.Payload,
+	)
+	return err
+}
+
+const getCalendarParticipant = `-- name: GetCalendarParticipant :one
+SELECT "id"
+FROM participants
+WHERE
+    trip_id = $1
+    AND calendar_token = $2
+    AND rsvp_status <> 'declined'
+`
+
+type GetCalendarParticipantParams struct {
+	TripID        uuid.UUID
+	CalendarToken uuid.UUID
+}
+
+func (q *Queries) GetCalendarParticipant(ctx context.Context, arg GetCalendarParticipantParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, getCalendarParticipant, arg.TripID, arg.CalendarToken)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getParticipant = `-- name: GetParticipant :one
 SELECT
-    "id", "trip_id", "email", "is_confirmed"
+    participants."id", participants."trip_id", participants."user_id", users."email", users."name",
+    participants."rsvp_status", participants."role", participants."notify_changes"
 FROM participants
+JOIN users ON users.id = participants.user_id
 WHERE
-    id = $1
+    participants.id = $1
 `
 
-func (q *Queries) GetParticipant(ctx context.Context, id uuid.UUID) (Participant, error) {
+type GetParticipantRow struct {
+	ID            uuid.UUID
+	TripID        uuid.UUID
+	UserID        uuid.UUID
+	Email         string
+	Name          pgtype.Text
+	RsvpStatus    string
+	Role          string
+	NotifyChanges bool
+}
+
+func (q *Queries) GetParticipant(ctx context.Context, id uuid.UUID) (GetParticipantRow, error) {
 	row := q.db.QueryRow(ctx, getParticipant, id)
-	var i Participant
+	var i GetParticipantRow
 	err := row.Scan(
 		&i.ID,
 		&i.TripID,
+		&i.UserID,
 		&i.Email,
-		&i.IsConfirmed,
+		&i.Name,
+		&i.RsvpStatus,
+		&i.Role,
+		&i.NotifyChanges,
 	)
 	return i, err
 }
 
+const getParticipantCalendarTokens = `-- name: GetParticipantCalendarTokens :many
+SELECT "id", "calendar_token"
+FROM participants
+WHERE id = ANY($1::uuid[])
+`
+
+type GetParticipantCalendarTokensRow struct {
+	ID            uuid.UUID
+	CalendarToken uuid.UUID
+}
+
+func (q *Queries) GetParticipantCalendarTokens(ctx context.Context, ids []uuid.UUID) ([]GetParticipantCalendarTokensRow, error) {
+	rows, err := q.db.Query(ctx, getParticipantCalendarTokens, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetParticipantCalendarTokensRow
+	for rows.Next() {
+		var i GetParticipantCalendarTokensRow
+		if err := rows.Scan(&i.ID, &i.CalendarToken); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getParticipantEmails = `-- name: GetParticipantEmails :many
+SELECT
+    "id", "kind", "recipient", "status", "attempts", "last_error",
+    "next_attempt_at", "created_at", "sent_at"
+FROM email_outbox
+WHERE
+    participant_id = $1
+ORDER BY created_at DESC
+`
+
+type GetParticipantEmailsRow struct {
+	ID            uuid.UUID
+	Kind          string
+	Recipient     string
+	Status        string
+	Attempts      int32
+	LastError     pgtype.Text
+	NextAttemptAt pgtype.Timestamptz
+	CreatedAt     pgtype.Timestamptz
+	SentAt        pgtype.Timestamptz
+}
+
+func (q *Queries) GetParticipantEmails(ctx context.Context, participantID pgtype.UUID) ([]GetParticipantEmailsRow, error) {
+	rows, err := q.db.Query(ctx, getParticipantEmails, participantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetParticipantEmailsRow
+	for rows.Next() {
+		var i GetParticipantEmailsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Recipient,
+			&i.Status,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getParticipants = `-- name: GetParticipants :many
 SELECT
-    "id", "trip_id", "email", "is_confirmed"
+    participants."id", participants."trip_id", participants."user_id", users."email", users."name",
+    participants."rsvp_status", participants."role", participants."notify_changes"
 FROM participants
+JOIN users ON users.id = participants.user_id
 WHERE
-    trip_id = $1
+    participants.trip_id = $1
 `
 
-func (q *Queries) GetParticipants(ctx context.Context, tripID uuid.UUID) ([]Participant, error) {
+type GetParticipantsRow struct {
+	ID            uuid.UUID
+	TripID        uuid.UUID
+	UserID        uuid.UUID
+	Email         string
+	Name          pgtype.Text
+	RsvpStatus    string
+	Role          string
+	NotifyChanges bool
+}
+
+func (q *Queries) GetParticipants(ctx context.Context, tripID uuid.UUID) ([]GetParticipantsRow, error) {
 	rows, err := q.db.Query(ctx, getParticipants, tripID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Participant
+	var items []GetParticipantsRow
 	for rows.Next() {
-		var i Participant
+		var i GetParticipantsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.TripID,
+			&i.UserID,
 			&i.Email,
-			&i.IsConfirmed,
+			&i.Name,
+			&i.RsvpStatus,
+			&i.Role,
+			&i.NotifyChanges,
 		); err != nil {
 			return nil, err
 		}
@@ -118,33 +478,53 @@ func (q *Queries) GetParticipants(ctx context.Context, tripID uuid.UUID) ([]Part
 
 const getTrip = `-- name: GetTrip :one
 SELECT
-    "id", "destination", "owner_email", "owner_name", "is_confirmed", "starts_at", "ends_at"
+    trips."id", trips."destination", trips."owner_id", users."email" AS owner_email, users."name" AS owner_name,
+    trips."status", trips."starts_at", trips."ends_at", trips."timezone", trips."version"
 FROM trips
+JOIN users ON users.id = trips.owner_id
 WHERE
-    id = $1
+    trips.id = $1
+    AND trips.deleted_at IS NULL
 `
 
-func (q *Queries) GetTrip(ctx context.Context, id uuid.UUID) (Trip, error) {
+type GetTripRow struct {
+	ID          uuid.UUID
+	Destination string
+	OwnerID     uuid.UUID
+	OwnerEmail  string
+	OwnerName   pgtype.Text
+	Status      string
+	StartsAt    pgtype.Timestamptz
+	EndsAt      pgtype.Timestamptz
+	Timezone    string
+	Version     int32
+}
+
+func (q *Queries) GetTrip(ctx context.Context, id uuid.UUID) (GetTripRow, error) {
 	row := q.db.QueryRow(ctx, getTrip, id)
-	var i Trip
+	var i GetTripRow
 	err := row.Scan(
 		&i.ID,
 		&i.Destination,
+		&i.OwnerID,
 		&i.OwnerEmail,
 		&i.OwnerName,
-		&i.IsConfirmed,
+		&i.Status,
 		&i.StartsAt,
 		&i.EndsAt,
+		&i.Timezone,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getTripActivities = `-- name: GetTripActivities :many
 SELECT
-    "id", "trip_id", "title", "occurs_at"
+    "id", "trip_id", "title", "occurs_at", "timezone", "version"
 FROM activities
 WHERE
     trip_id = $1
+ORDER BY occurs_at, id
 `
 
 func (q *Queries) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]Activity, error) {
@@ -161,6 +541,8 @@ func (q *Queries) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]Ac
 			&i.TripID,
 			&i.Title,
 			&i.OccursAt,
+			&i.Timezone,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -172,12 +554,69 @@ func (q *Queries) GetTripActivities(ctx context.Context, tripID uuid.UUID) ([]Ac
 	return items, nil
 }
 
+const getTripActivity = `-- name: GetTripActivity :one
+SELECT
+    "id", "trip_id", "title", "occurs_at", "timezone", "version"
+FROM activities
+WHERE
+    id = $1
+    AND trip_id = $2
+`
+
+type GetTripActivityParams struct {
+	ID     uuid.UUID
+	TripID uuid.UUID
+}
+
+func (q *Queries) GetTripActivity(ctx context.Context, arg GetTripActivityParams) (Activity, error) {
+	row := q.db.QueryRow(ctx, getTripActivity, arg.ID, arg.TripID)
+	var i Activity
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Title,
+		&i.OccursAt,
+		&i.Timezone,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getTripLink = `-- name: GetTripLink :one
+SELECT
+    "id", "trip_id", "title", "url", "position", "version"
+FROM links
+WHERE
+    id = $1
+    AND trip_id = $2
+`
+
+type GetTripLinkParams struct {
+	ID     uuid.UUID
+	TripID uuid.UUID
+}
+
+func (q *Queries) GetTripLink(ctx context.Context, arg GetTripLinkParams) (Link, error) {
+	row := q.db.QueryRow(ctx, getTripLink, arg.ID, arg.TripID)
+	var i Link
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Title,
+		&i.Url,
+		&i.Position,
+		&i.Version,
+	)
+	return i, err
+}
+
 const getTripLinks = `-- name: GetTripLinks :many
 SELECT
-    "id", "trip_id", "title", "url"
+    "id", "trip_id", "title", "url", "position", "version"
 FROM links
 WHERE
     trip_id = $1
+ORDER BY "position", "id"
 `
 
 func (q *Queries) GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]Link, error) {
@@ -194,6 +633,8 @@ func (q *Queries) GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]Link, e
 			&i.TripID,
 			&i.Title,
 			&i.Url,
+			&i.Position,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -205,28 +646,210 @@ func (q *Queries) GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]Link, e
 	return items, nil
 }
 
+const getTripParticipantByUser = `-- name: GetTripParticipantByUser :one
+SELECT
+    "id", "trip_id", "role", "user_id", "rsvp_status", "invited_at", "notify_changes", "calendar_token"
+FROM participants
+WHERE
+    trip_id = $1
+    AND user_id = $2
+`
+
+type GetTripParticipantByUserParams struct {
+	TripID uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) GetTripParticipantByUser(ctx context.Context, arg GetTripParticipantByUserParams) (Participant, error) {
+	row := q.db.QueryRow(ctx, getTripParticipantByUser, arg.TripID, arg.UserID)
+	var i Participant
+	err := row.Scan(
+		&i.ID,
+		&i.TripID,
+		&i.Role,
+		&i.UserID,
+		&i.RsvpStatus,
+		&i.InvitedAt,
+		&i.NotifyChanges,
+		&i.CalendarToken,
+	)
+	return i, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT
+    "id", "email", "name", "locale", "timezone", "is_admin"
+FROM users
+WHERE
+    id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.Locale,
+		&i.Timezone,
+		&i.IsAdmin,
+	)
+	return i, err
+}
+
+const getUserLocales = `-- name: GetUserLocales :many
+SELECT
+    "email", "locale"
+FROM users
+WHERE
+    email = ANY($1::text[])
+`
+
+type GetUserLocalesRow struct {
+	Email  string
+	Locale pgtype.Text
+}
+
+func (q *Queries) GetUserLocales(ctx context.Context, emails []string) ([]GetUserLocalesRow, error) {
+	rows, err := q.db.Query(ctx, getUserLocales, emails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserLocalesRow
+	for rows.Next() {
+		var i GetUserLocalesRow
+		if err := rows.Scan(&i.Email, &i.Locale); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertConfirmationToken = `-- name: InsertConfirmationToken :one
+INSERT INTO confirmation_tokens
+    ( "trip_id", "participant_id", "expires_at" ) VALUES
+    ( $1, $2, $3 )
+RETURNING "id"
+`
+
+type InsertConfirmationTokenParams struct {
+	TripID        uuid.UUID
+	ParticipantID pgtype.UUID
+	ExpiresAt     pgtype.Timestamptz
+}
+
+func (q *Queries) InsertConfirmationToken(ctx context.Context, arg InsertConfirmationTokenParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, insertConfirmationToken, arg.TripID, arg.ParticipantID, arg.ExpiresAt)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertInviteConfirmationTokens = `-- name: InsertInviteConfirmationTokens :many
+INSERT INTO confirmation_tokens
+    ( "trip_id", "participant_id", "expires_at" )
+SELECT $1::uuid, UNNEST($2::uuid[]), $3::timestamptz
+RETURNING "id", "participant_id"
+`
+
+type InsertInviteConfirmationTokensParams struct {
+	TripID         uuid.UUID
+	ParticipantIds []uuid.UUID
+	ExpiresAt      pgtype.Timestamptz
+}
+
+type InsertInviteConfirmationTokensRow struct {
+	ID            uuid.UUID
+	ParticipantID pgtype.UUID
+}
+
+func (q *Queries) InsertInviteConfirmationTokens(ctx context.Context, arg InsertInviteConfirmationTokensParams) ([]InsertInviteConfirmationTokensRow, error) {
+	rows, err := q.db.Query(ctx, insertInviteConfirmationTokens, arg.TripID, arg.ParticipantIds, arg.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InsertInviteConfirmationTokensRow
+	for rows.Next() {
+		var i InsertInviteConfirmationTokensRow
+		if err := rows.Scan(&i.ID, &i.ParticipantID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertEmailChangeToken = `-- name: InsertEmailChangeToken :one
+INSERT INTO email_change_tokens
+    ( "user_id", "email", "expires_at" ) VALUES
+    ( $1, $2, $3 )
+RETURNING "id"
+`
+
+type InsertEmailChangeTokenParams struct {
+	UserID    uuid.UUID
+	Email     string
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) InsertEmailChangeToken(ctx context.Context, arg InsertEmailChangeTokenParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, insertEmailChangeToken, arg.UserID, arg.Email, arg.ExpiresAt)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertLoginToken = `-- name: InsertLoginToken :one
+INSERT INTO login_tokens
+    ( "email", "expires_at" ) VALUES
+    ( $1, $2 )
+RETURNING "id"
+`
+
+type InsertLoginTokenParams struct {
+	Email     string
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) InsertLoginToken(ctx context.Context, arg InsertLoginTokenParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, insertLoginToken, arg.Email, arg.ExpiresAt)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const insertTrip = `-- name: InsertTrip :one
 INSERT INTO trips
-    ( "destination", "owner_email", "owner_name", "starts_at", "ends_at" ) VALUES
+    ( "destination", "owner_id", "starts_at", "ends_at", "timezone" ) VALUES
     ( $1, $2, $3, $4, $5 )
 RETURNING "id"
 `
 
 type InsertTripParams struct {
 	Destination string
-	OwnerEmail  string
-	OwnerName   string
-	StartsAt    pgtype.Timestamp
-	EndsAt      pgtype.Timestamp
+	OwnerID     uuid.UUID
+	StartsAt    pgtype.Timestamptz
+	EndsAt      pgtype.Timestamptz
+	Timezone    string
 }
 
 func (q *Queries) InsertTrip(ctx context.Context, arg InsertTripParams) (uuid.UUID, error) {
 	row := q.db.QueryRow(ctx, insertTrip,
 		arg.Destination,
-		arg.OwnerEmail,
-		arg.OwnerName,
+		arg.OwnerID,
 		arg.StartsAt,
 		arg.EndsAt,
+		arg.Timezone,
 	)
 	var id uuid.UUID
 	err := row.Scan(&id)
@@ -235,18 +858,19 @@ func (q *Queries) InsertTrip(ctx context.Context, arg InsertTripParams) (uuid.UU
 
 const inviteParticipantToTrip = `-- name: InviteParticipantToTrip :one
 INSERT INTO participants
-    ( "trip_id", "email" ) VALUES
+    ( "trip_id", "user_id" ) VALUES
     ( $1, $2 )
+ON CONFLICT ("trip_id", "user_id") DO NOTHING
 RETURNING "id"
 `
 
 type InviteParticipantToTripParams struct {
 	TripID uuid.UUID
-	Email  string
+	UserID uuid.UUID
 }
 
 func (q *Queries) InviteParticipantToTrip(ctx context.Context, arg InviteParticipantToTripParams) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, inviteParticipantToTrip, arg.TripID, arg.Email)
+	row := q.db.QueryRow(ctx, inviteParticipantToTrip, arg.TripID, arg.UserID)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
@@ -254,35 +878,484 @@ func (q *Queries) InviteParticipantToTrip(ctx context.Context, arg InvitePartici
 
 type InviteParticipantsToTripParams struct {
 	TripID uuid.UUID
-	Email  string
+	UserID uuid.UUID
+}
+
+const listUserTrips = `-- name: ListUserTrips :many
+SELECT
+    "id", "destination", "starts_at", "ends_at", "owner_id", "status", "deleted_at", "timezone", "version"
+FROM trips
+WHERE
+    deleted_at IS NULL
+    AND (
+        owner_id = $1
+        OR id IN (SELECT trip_id FROM participants WHERE user_id = $1)
+    )
+    AND (
+        $2::text = ''
+        OR ($2::text = 'upcoming' AND ends_at >= NOW() AND status NOT IN ('cancelled', 'archived'))
+        OR ($2::text = 'past' AND ends_at < NOW())
+        OR ($2::text = 'unconfirmed' AND status = 'draft')
+    )
+ORDER BY
+    CASE WHEN $3::boolean THEN starts_at END DESC,
+    starts_at ASC,
+    id
+LIMIT $4
+OFFSET $5
+`
+
+type ListUserTripsParams struct {
+	UserID     uuid.UUID
+	Filter     string
+	SortDesc   bool
+	PageLimit  int32
+	PageOffset int32
+}
+
+func (q *Queries) ListUserTrips(ctx context.Context, arg ListUserTripsParams) ([]Trip, error) {
+	rows, err := q.db.Query(ctx, listUserTrips,
+		arg.UserID,
+		arg.Filter,
+		arg.SortDesc,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Trip
+	for rows.Next() {
+		var i Trip
+		if err := rows.Scan(
+			&i.ID,
+			&i.Destination,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.OwnerID,
+			&i.Status,
+			&i.DeletedAt,
+			&i.Timezone,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markEmailFailed = `-- name: MarkEmailFailed :exec
+UPDATE email_outbox
+SET
+    "status" = $1,
+    "last_error" = $2,
+    "next_attempt_at" = $3
+WHERE
+    id = $4
+`
+
+type MarkEmailFailedParams struct {
+	Status        string
+	LastError     pgtype.Text
+	NextAttemptAt pgtype.Timestamptz
+	ID            uuid.UUID
+}
+
+func (q *Queries) MarkEmailFailed(ctx context.Context, arg MarkEmailFailedParams) error {
+	_, err := q.db.Exec(ctx, markEmailFailed,
+		arg.Status,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.ID,
+	)
+	return err
+}
+
+const markEmailSent = `-- name: MarkEmailSent :exec
+UPDATE email_outbox
+SET
+    "status" = 'sent',
+    "last_error" = NULL,
+    "sent_at" = NOW()
+WHERE
+    id = $1
+`
+
+func (q *Queries) MarkEmailSent(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, markEmailSent, id)
+	return err
+}
+
+const markParticipantInvited = `-- name: MarkParticipantInvited :execrows
+UPDATE participants
+SET "invited_at" = $1
+WHERE
+    id = $2
+    AND trip_id = $3
+    AND (invited_at IS NULL OR invited_at < $4)
+`
+
+type MarkParticipantInvitedParams struct {
+	InvitedAt     pgtype.Timestamptz
+	ID            uuid.UUID
+	TripID        uuid.UUID
+	InvitedBefore pgtype.Timestamptz
+}
+
+func (q *Queries) MarkParticipantInvited(ctx context.Context, arg MarkParticipantInvitedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markParticipantInvited,
+		arg.InvitedAt,
+		arg.ID,
+		arg.TripID,
+		arg.InvitedBefore,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markPendingParticipantsInvited = `-- name: MarkPendingParticipantsInvited :many
+UPDATE participants
+SET "invited_at" = $1
+FROM users
+WHERE
+    users.id = participants.user_id
+    AND participants.trip_id = $2
+    AND participants.rsvp_status = 'pending'
+    AND participants.invited_at IS NULL
+RETURNING participants."id", users."email"
+`
+
+type MarkPendingParticipantsInvitedParams struct {
+	InvitedAt pgtype.Timestamptz
+	TripID    uuid.UUID
 }
 
-const updateTrip = `-- name: UpdateTrip :exec
+type MarkPendingParticipantsInvitedRow struct {
+	ID    uuid.UUID
+	Email string
+}
+
+func (q *Queries) MarkPendingParticipantsInvited(ctx context.Context, arg MarkPendingParticipantsInvitedParams) ([]MarkPendingParticipantsInvitedRow, error) {
+	rows, err := q.db.Query(ctx, markPendingParticipantsInvited, arg.InvitedAt, arg.TripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MarkPendingParticipantsInvitedRow
+	for rows.Next() {
+		var i MarkPendingParticipantsInvitedRow
+		if err := rows.Scan(&i.ID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setLinkPosition = `-- name: SetLinkPosition :execrows
+UPDATE links
+SET
+    "position" = $1,
+    "version" = "version" + 1
+WHERE
+    id = $2
+    AND trip_id = $3
+`
+
+type SetLinkPositionParams struct {
+	Position int32
+	ID       uuid.UUID
+	TripID   uuid.UUID
+}
+
+func (q *Queries) SetLinkPosition(ctx context.Context, arg SetLinkPositionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setLinkPosition, arg.Position, arg.ID, arg.TripID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateActivity = `-- name: UpdateActivity :one
+UPDATE activities
+SET
+    "title" = $1,
+    "occurs_at" = $2,
+    "timezone" = $3,
+    "version" = "version" + 1
+WHERE
+    id = $4
+    AND trip_id = $5
+    AND "version" = $6
+RETURNING "version"
+`
+
+type UpdateActivityParams struct {
+	Title    string
+	OccursAt pgtype.Timestamptz
+	Timezone pgtype.Text
+	ID       uuid.UUID
+	TripID   uuid.UUID
+	Version  int32
+}
+
+func (q *Queries) UpdateActivity(ctx context.Context, arg UpdateActivityParams) (int32, error) {
+	row := q.db.QueryRow(ctx, updateActivity,
+		arg.Title,
+		arg.OccursAt,
+		arg.Timezone,
+		arg.ID,
+		arg.TripID,
+		arg.Version,
+	)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const updateLink = `-- name: UpdateLink :one
+UPDATE links
+SET
+    "title" = $1,
+    "url" = $2,
+    "version" = "version" + 1
+WHERE
+    id = $3
+    AND trip_id = $4
+    AND "version" = $5
+RETURNING "version"
+`
+
+type UpdateLinkParams struct {
+	Title   string
+	Url     string
+	ID      uuid.UUID
+	TripID  uuid.UUID
+	Version int32
+}
+
+func (q *Queries) UpdateLink(ctx context.Context, arg UpdateLinkParams) (int32, error) {
+	row := q.db.QueryRow(ctx, updateLink,
+		arg.Title,
+		arg.Url,
+		arg.ID,
+		arg.TripID,
+		arg.Version,
+	)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const updateParticipantNotifyChanges = `-- name: UpdateParticipantNotifyChanges :execrows
+UPDATE participants
+SET "notify_changes" = $1
+WHERE id = $2
+`
+
+type UpdateParticipantNotifyChangesParams struct {
+	NotifyChanges bool
+	ID            uuid.UUID
+}
+
+func (q *Queries) UpdateParticipantNotifyChanges(ctx context.Context, arg UpdateParticipantNotifyChangesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateParticipantNotifyChanges, arg.NotifyChanges, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateParticipantRole = `-- name: UpdateParticipantRole :exec
+UPDATE participants
+SET "role" = $1
+WHERE id = $2
+`
+
+type UpdateParticipantRoleParams struct {
+	Role string
+	ID   uuid.UUID
+}
+
+func (q *Queries) UpdateParticipantRole(ctx context.Context, arg UpdateParticipantRoleParams) error {
+	_, err := q.db.Exec(ctx, updateParticipantRole, arg.Role, arg.ID)
+	return err
+}
+
+const updateParticipantRsvp = `-- name: UpdateParticipantRsvp :execrows
+UPDATE participants
+SET "rsvp_status" = $1
+WHERE
+    id = $2
+    AND rsvp_status = 'pending'
+`
+
+type UpdateParticipantRsvpParams struct {
+	RsvpStatus string
+	ID         uuid.UUID
+}
+
+func (q *Queries) UpdateParticipantRsvp(ctx context.Context, arg UpdateParticipantRsvpParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateParticipantRsvp, arg.RsvpStatus, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateTrip = `-- name: UpdateTrip :one
 UPDATE trips
 SET
-    "destination" = $1,
-    "ends_at" = $2,
-    "starts_at" = $3,
-    "is_confirmed" = $4
+    "destination" = COALESCE($1, "destination"),
+    "ends_at" = COALESCE($2, "ends_at"),
+    "starts_at" = COALESCE($3, "starts_at"),
+    "status" = COALESCE($4, "status"),
+    "timezone" = COALESCE($5, "timezone"),
+    "version" = "version" + 1
 WHERE
-    id = $5
+    id = $6
+    AND "version" = $7
+RETURNING "id", "destination", "starts_at", "ends_at", "owner_id", "status", "deleted_at", "timezone", "version"
 `
 
 type UpdateTripParams struct {
-	Destination string
-	EndsAt      pgtype.Timestamp
-	StartsAt    pgtype.Timestamp
-	IsConfirmed bool
+	Destination pgtype.Text
+	EndsAt      pgtype.Timestamptz
+	StartsAt    pgtype.Timestamptz
+	Status      pgtype.Text
+	Timezone    pgtype.Text
 	ID          uuid.UUID
+	Version     int32
 }
 
-func (q *Queries) UpdateTrip(ctx context.Context, arg UpdateTripParams) error {
-	_, err := q.db.Exec(ctx, updateTrip,
+func (q *Queries) UpdateTrip(ctx context.Context, arg UpdateTripParams) (Trip, error) {
+	row := q.db.QueryRow(ctx, updateTrip,
 		arg.Destination,
 		arg.EndsAt,
 		arg.StartsAt,
-		arg.IsConfirmed,
+		arg.Status,
+		arg.Timezone,
+		arg.ID,
+		arg.Version,
+	)
+	var i Trip
+	err := row.Scan(
+		&i.ID,
+		&i.Destination,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.OwnerID,
+		&i.Status,
+		&i.DeletedAt,
+		&i.Timezone,
+		&i.Version,
+	)
+	return i, err
+}
+
+const updateTripStatus = `-- name: UpdateTripStatus :execrows
+UPDATE trips
+SET
+    "status" = $1,
+    "version" = "version" + 1
+WHERE
+    id = $2
+    AND status = $3
+    AND deleted_at IS NULL
+`
+
+type UpdateTripStatusParams struct {
+	ToStatus   string
+	ID         uuid.UUID
+	FromStatus string
+}
+
+func (q *Queries) UpdateTripStatus(ctx context.Context, arg UpdateTripStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateTripStatus, arg.ToStatus, arg.ID, arg.FromStatus)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUser = `-- name: UpdateUser :exec
+UPDATE users
+SET
+    "name" = $1,
+    "locale" = $2,
+    "timezone" = $3
+WHERE
+    id = $4
+`
+
+type UpdateUserParams struct {
+	Name     pgtype.Text
+	Locale   pgtype.Text
+	Timezone pgtype.Text
+	ID       uuid.UUID
+}
+
+func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
+	_, err := q.db.Exec(ctx, updateUser,
+		arg.Name,
+		arg.Locale,
+		arg.Timezone,
 		arg.ID,
 	)
 	return err
 }
+
+const updateUserEmail = `-- name: UpdateUserEmail :execrows
+UPDATE users
+SET "email" = $1
+WHERE
+    id = $2
+    AND NOT EXISTS (
+        SELECT 1 FROM users AS other
+        WHERE other.email = $1 AND other.id <> $2
+    )
+`
+
+type UpdateUserEmailParams struct {
+	Email string
+	ID    uuid.UUID
+}
+
+func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserEmail, arg.Email, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertUser = `-- name: UpsertUser :one
+INSERT INTO users
+    ( "email", "name" ) VALUES
+    ( $1, $2 )
+ON CONFLICT ("email") DO UPDATE
+SET "name" = COALESCE(users.name, EXCLUDED.name)
+RETURNING "id"
+`
+
+type UpsertUserParams struct {
+	Email string
+	Name  pgtype.Text
+}
+
+func (q *Queries) UpsertUser(ctx context.Context, arg UpsertUserParams) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, upsertUser, arg.Email, arg.Name)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
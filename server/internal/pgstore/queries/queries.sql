@@ -1,78 +1,396 @@
 -- name: InsertTrip :one
 INSERT INTO trips
-    ( "destination", "owner_email", "owner_name", "starts_at", "ends_at" ) VALUES
+    ( "destination", "owner_id", "starts_at", "ends_at", "timezone" ) VALUES
     ( $1, $2, $3, $4, $5 )
 RETURNING "id";
 
 -- name: GetTrip :one
 SELECT
-    "id", "destination", "owner_email", "owner_name", "is_confirmed", "starts_at", "ends_at"
+    trips."id", trips."destination", trips."owner_id", users."email" AS owner_email, users."name" AS owner_name,
+    trips."status", trips."starts_at", trips."ends_at", trips."timezone", trips."version"
 FROM trips
+JOIN users ON users.id = trips.owner_id
 WHERE
-    id = $1;
+    trips.id = $1
+    AND trips.deleted_at IS NULL;
 
--- name: UpdateTrip :exec
+-- name: ListUserTrips :many
+SELECT
+    "id", "destination", "starts_at", "ends_at", "owner_id", "status", "deleted_at", "timezone", "version"
+FROM trips
+WHERE
+    deleted_at IS NULL
+    AND (
+        owner_id = @user_id
+        OR id IN (SELECT trip_id FROM participants WHERE user_id = @user_id)
+    )
+    AND (
+        @filter::text = ''
+        OR (@filter::text = 'upcoming' AND ends_at >= NOW() AND status NOT IN ('cancelled', 'archived'))
+        OR (@filter::text = 'past' AND ends_at < NOW())
+        OR (@filter::text = 'unconfirmed' AND status = 'draft')
+    )
+ORDER BY
+    CASE WHEN @sort_desc::boolean THEN starts_at END DESC,
+    starts_at ASC,
+    id
+LIMIT @page_limit
+OFFSET @page_offset;
+
+-- name: UpdateTrip :one
 UPDATE trips
 SET
-    "destination" = $1,
-    "ends_at" = $2,
-    "starts_at" = $3,
-    "is_confirmed" = $4
+    "destination" = COALESCE(sqlc.narg('destination'), "destination"),
+    "ends_at" = COALESCE(sqlc.narg('ends_at'), "ends_at"),
+    "starts_at" = COALESCE(sqlc.narg('starts_at'), "starts_at"),
+    "status" = COALESCE(sqlc.narg('status'), "status"),
+    "timezone" = COALESCE(sqlc.narg('timezone'), "timezone"),
+    "version" = "version" + 1
 WHERE
-    id = $5;
+    id = @id
+    AND "version" = @version
+RETURNING "id", "destination", "starts_at", "ends_at", "owner_id", "status", "deleted_at", "timezone", "version";
+
+-- name: UpdateTripStatus :execrows
+UPDATE trips
+SET
+    "status" = @to_status,
+    "version" = "version" + 1
+WHERE
+    id = @id
+    AND status = @from_status
+    AND deleted_at IS NULL;
+
+-- name: DeleteTrip :execrows
+UPDATE trips
+SET "deleted_at" = NOW()
+WHERE
+    id = $1
+    AND deleted_at IS NULL;
 
 -- name: GetParticipant :one
 SELECT
-    "id", "trip_id", "email", "is_confirmed"
+    participants."id", participants."trip_id", participants."user_id", users."email", users."name",
+    participants."rsvp_status", participants."role", participants."notify_changes"
 FROM participants
+JOIN users ON users.id = participants.user_id
 WHERE
-    id = $1;
+    participants.id = $1;
 
--- name: ConfirmParticipant :exec
+-- name: GetTripParticipantByUser :one
+SELECT
+    "id", "trip_id", "role", "user_id", "rsvp_status", "invited_at", "notify_changes", "calendar_token"
+FROM participants
+WHERE
+    trip_id = $1
+    AND user_id = $2;
+
+-- name: UpdateParticipantRsvp :execrows
+UPDATE participants
+SET "rsvp_status" = $1
+WHERE
+    id = $2
+    AND rsvp_status = 'pending';
+
+-- name: MarkParticipantInvited :execrows
 UPDATE participants
-SET "is_confirmed" = true
-WHERE id = $1;
+SET "invited_at" = @invited_at
+WHERE
+    id = @id
+    AND trip_id = @trip_id
+    AND (invited_at IS NULL OR invited_at < @invited_before);
+
+-- name: MarkPendingParticipantsInvited :many
+UPDATE participants
+SET "invited_at" = @invited_at
+FROM users
+WHERE
+    users.id = participants.user_id
+    AND participants.trip_id = @trip_id
+    AND participants.rsvp_status = 'pending'
+    AND participants.invited_at IS NULL
+RETURNING participants."id", users."email";
+
+-- name: DeleteParticipant :execrows
+DELETE FROM participants
+WHERE
+    id = $1
+    AND trip_id = $2;
 
 -- name: GetParticipants :many
 SELECT
-    "id", "trip_id", "email", "is_confirmed"
+    participants."id", participants."trip_id", participants."user_id", users."email", users."name",
+    participants."rsvp_status", participants."role", participants."notify_changes"
 FROM participants
+JOIN users ON users.id = participants.user_id
 WHERE
-    trip_id = $1;
+    participants.trip_id = $1;
+
+-- name: UpdateParticipantNotifyChanges :execrows
+UPDATE participants
+SET "notify_changes" = $1
+WHERE id = $2;
+
+-- name: UpdateParticipantRole :exec
+UPDATE participants
+SET "role" = $1
+WHERE id = $2;
+
+-- name: GetParticipantCalendarTokens :many
+SELECT "id", "calendar_token"
+FROM participants
+WHERE id = ANY(@ids::uuid[]);
+
+-- name: GetCalendarParticipant :one
+SELECT "id"
+FROM participants
+WHERE
+    trip_id = $1
+    AND calendar_token = $2
+    AND rsvp_status <> 'declined';
 
 -- name: InviteParticipantToTrip :one
 INSERT INTO participants
-    ( "trip_id", "email" ) VALUES
+    ( "trip_id", "user_id" ) VALUES
     ( $1, $2 )
+ON CONFLICT ("trip_id", "user_id") DO NOTHING
 RETURNING "id";
 
 -- name: InviteParticipantsToTrip :copyfrom
 INSERT INTO participants
-    ( "trip_id", "email" ) VALUES
+    ( "trip_id", "user_id" ) VALUES
     ( $1, $2 );
 
 -- name: CreateActivity :one
 INSERT INTO activities
-    ( "trip_id", "title", "occurs_at" ) VALUES
-    ( $1, $2, $3 )
+    ( "trip_id", "title", "occurs_at", "timezone" ) VALUES
+    ( $1, $2, $3, $4 )
 RETURNING "id";
 
 -- name: GetTripActivities :many
 SELECT
-    "id", "trip_id", "title", "occurs_at"
+    "id", "trip_id", "title", "occurs_at", "timezone", "version"
+FROM activities
+WHERE
+    trip_id = $1
+ORDER BY occurs_at, id;
+
+-- name: GetTripActivity :one
+SELECT
+    "id", "trip_id", "title", "occurs_at", "timezone", "version"
 FROM activities
 WHERE
-    trip_id = $1;
+    id = $1
+    AND trip_id = $2;
+
+-- name: UpdateActivity :one
+UPDATE activities
+SET
+    "title" = $1,
+    "occurs_at" = $2,
+    "timezone" = $3,
+    "version" = "version" + 1
+WHERE
+    id = $4
+    AND trip_id = $5
+    AND "version" = $6
+RETURNING "version";
+
+-- name: DeleteActivity :execrows
+DELETE FROM activities
+WHERE
+    id = $1
+    AND trip_id = $2;
+
+-- name: DeleteActivities :execrows
+DELETE FROM activities
+WHERE
+    trip_id = @trip_id
+    AND id = ANY(@ids::uuid[]);
 
 -- name: CreateTripLink :one
 INSERT INTO links
-    ( "trip_id", "title", "url" ) VALUES
-    ( $1, $2, $3 )
+    ( "trip_id", "title", "url", "position" ) VALUES
+    ( $1, $2, $3, (SELECT COALESCE(MAX(position) + 1, 0) FROM links WHERE trip_id = $1) )
 RETURNING "id";
 
 -- name: GetTripLinks :many
 SELECT
-    "id", "trip_id", "title", "url"
+    "id", "trip_id", "title", "url", "position", "version"
+FROM links
+WHERE
+    trip_id = $1
+ORDER BY "position", "id";
+
+-- name: GetTripLink :one
+SELECT
+    "id", "trip_id", "title", "url", "position", "version"
 FROM links
 WHERE
-    trip_id = $1;
\ No newline at end of file
+    id = $1
+    AND trip_id = $2;
+
+-- name: UpdateLink :one
+UPDATE links
+SET
+    "title" = $1,
+    "url" = $2,
+    "version" = "version" + 1
+WHERE
+    id = $3
+    AND trip_id = $4
+    AND "version" = $5
+RETURNING "version";
+
+-- name: DeleteLink :execrows
+DELETE FROM links
+WHERE
+    id = $1
+    AND trip_id = $2;
+
+-- name: SetLinkPosition :execrows
+UPDATE links
+SET
+    "position" = $1,
+    "version" = "version" + 1
+WHERE
+    id = $2
+    AND trip_id = $3;
+
+-- name: InsertLoginToken :one
+INSERT INTO login_tokens
+    ( "email", "expires_at" ) VALUES
+    ( $1, $2 )
+RETURNING "id";
+
+-- name: ConsumeLoginToken :execrows
+UPDATE login_tokens
+SET "consumed_at" = NOW()
+WHERE
+    id = $1
+    AND consumed_at IS NULL;
+
+-- name: InsertEmailChangeToken :one
+INSERT INTO email_change_tokens
+    ( "user_id", "email", "expires_at" ) VALUES
+    ( $1, $2, $3 )
+RETURNING "id";
+
+-- name: ConsumeEmailChangeToken :one
+UPDATE email_change_tokens
+SET "consumed_at" = NOW()
+WHERE
+    id = $1
+    AND user_id = $2
+    AND consumed_at IS NULL
+RETURNING "email";
+
+-- name: InsertConfirmationToken :one
+INSERT INTO confirmation_tokens
+    ( "trip_id", "participant_id", "expires_at" ) VALUES
+    ( $1, $2, $3 )
+RETURNING "id";
+
+-- name: InsertInviteConfirmationTokens :many
+INSERT INTO confirmation_tokens
+    ( "trip_id", "participant_id", "expires_at" )
+SELECT @trip_id::uuid, UNNEST(@participant_ids::uuid[]), @expires_at::timestamptz
+RETURNING "id", "participant_id";
+
+-- name: ConsumeConfirmationToken :execrows
+UPDATE confirmation_tokens
+SET "consumed_at" = NOW()
+WHERE
+    id = $1
+    AND consumed_at IS NULL;
+
+-- name: UpsertUser :one
+INSERT INTO users
+    ( "email", "name" ) VALUES
+    ( $1, $2 )
+ON CONFLICT ("email") DO UPDATE
+SET "name" = COALESCE(users.name, EXCLUDED.name)
+RETURNING "id";
+
+-- name: GetUser :one
+SELECT
+    "id", "email", "name", "locale", "timezone", "is_admin"
+FROM users
+WHERE
+    id = $1;
+
+-- name: GetUserLocales :many
+SELECT
+    "email", "locale"
+FROM users
+WHERE
+    email = ANY(@emails::text[]);
+
+-- name: UpdateUser :exec
+UPDATE users
+SET
+    "name" = $1,
+    "locale" = $2,
+    "timezone" = $3
+WHERE
+    id = $4;
+
+-- name: UpdateUserEmail :execrows
+UPDATE users
+SET "email" = $1
+WHERE
+    id = $2
+    AND NOT EXISTS (
+        SELECT 1 FROM users AS other
+        WHERE other.email = $1 AND other.id <> $2
+    );
+
+-- name: EnqueueEmail :exec
+INSERT INTO email_outbox
+    ( "kind", "trip_id", "participant_id", "recipient", "payload" ) VALUES
+    ( $1, $2, $3, $4, $5 );
+
+-- name: ClaimDueEmails :many
+UPDATE email_outbox
+SET
+    "attempts" = "attempts" + 1,
+    "next_attempt_at" = NOW() + INTERVAL '5 minutes'
+WHERE id IN (
+    SELECT id FROM email_outbox
+    WHERE
+        status = 'pending'
+        AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING
+    "id", "kind", "trip_id", "participant_id", "recipient", "payload", "status",
+    "attempts", "last_error", "next_attempt_at", "created_at", "sent_at";
+
+-- name: MarkEmailSent :exec
+UPDATE email_outbox
+SET
+    "status" = 'sent',
+    "last_error" = NULL,
+    "sent_at" = NOW()
+WHERE
+    id = $1;
+
+-- name: MarkEmailFailed :exec
+UPDATE email_outbox
+SET
+    "status" = $1,
+    "last_error" = $2,
+    "next_attempt_at" = $3
+WHERE
+    id = $4;
+
+-- name: GetParticipantEmails :many
+SELECT
+    "id", "kind", "recipient", "status", "attempts", "last_error",
+    "next_attempt_at", "created_at", "sent_at"
+FROM email_outbox
+WHERE
+    participant_id = $1
+ORDER BY created_at DESC;
@@ -0,0 +1,14 @@
+package pgstore
+
+import "time"
+
+// Location returns the zone named by a timezone column, falling back to UTC
+// when it is unknown to the server.
+func Location(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
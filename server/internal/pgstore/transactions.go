@@ -2,14 +2,24 @@ package pgstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"server/internal/api/spec"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// NormalizeEmail is the form e-mail addresses are stored and compared in, the
+// one the users_email_normalized constraint checks.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 func (q *Queries) CreateTrip(ctx context.Context, pool *pgxpool.Pool, params spec.CreateTripRequest) (uuid.UUID, error) {
 	tx, err := pool.Begin(ctx)
 	if err != nil {
@@ -19,23 +29,49 @@ func (q *Queries) CreateTrip(ctx context.Context, pool *pgxpool.Pool, params spe
 	defer tx.Rollback(ctx)
 
 	qtx := q.WithTx(tx)
+	ownerID, err := qtx.UpsertUser(ctx, UpsertUserParams{
+		Email: NormalizeEmail(string(params.OwnerEmail)),
+		Name:  pgtype.Text{Valid: true, String: params.OwnerName},
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to upsert owner User for CreateTrip: %w", err)
+	}
+
+	timezone := "UTC"
+	if params.Timezone != nil && *params.Timezone != "" {
+		timezone = *params.Timezone
+	}
+
 	tripID, err := qtx.InsertTrip(ctx, InsertTripParams{
 		Destination: params.Destination,
-		OwnerEmail:  string(params.OwnerEmail),
-		OwnerName:   params.OwnerName,
-		StartsAt:    pgtype.Timestamp{Valid: true, Time: params.StartsAt},
-		EndsAt:      pgtype.Timestamp{Valid: true, Time: params.EndsAt},
+		OwnerID:     ownerID,
+		StartsAt:    pgtype.Timestamptz{Valid: true, Time: params.StartsAt},
+		EndsAt:      pgtype.Timestamptz{Valid: true, Time: params.EndsAt},
+		Timezone:    timezone,
 	})
 	if err != nil {
 		return uuid.UUID{}, fmt.Errorf("pgstore: failed to insert Trip for CreateTrip: %w", err)
 	}
 
-	participants := make([]InviteParticipantsToTripParams, len(params.EmailsToInvite))
-	for i, email := range params.EmailsToInvite {
-		participants[i] = InviteParticipantsToTripParams{
-			TripID: tripID,
-			Email:  string(email),
+	// The same address listed twice, or the owner listing themselves, would
+	// break the one participant per user and trip constraint and the copy with it.
+	participants := make([]InviteParticipantsToTripParams, 0, len(params.EmailsToInvite))
+	invited := map[uuid.UUID]bool{ownerID: true}
+	for _, email := range params.EmailsToInvite {
+		userID, err := qtx.UpsertUser(ctx, UpsertUserParams{Email: NormalizeEmail(string(email))})
+		if err != nil {
+			return uuid.UUID{}, fmt.Errorf("pgstore: failed to upsert participant User for CreateTrip: %w", err)
 		}
+
+		if invited[userID] {
+			continue
+		}
+		invited[userID] = true
+
+		participants = append(participants, InviteParticipantsToTripParams{
+			TripID: tripID,
+			UserID: userID,
+		})
 	}
 
 	_, err = qtx.InviteParticipantsToTrip(ctx, participants)
@@ -43,6 +79,16 @@ func (q *Queries) CreateTrip(ctx context.Context, pool *pgxpool.Pool, params spe
 		return uuid.UUID{}, fmt.Errorf("pgstore: failed to insert Participants for CreateTrip: %w", err)
 	}
 
+	err = qtx.EnqueueEmail(ctx, EnqueueEmailParams{
+		Kind:      EmailConfirmTrip,
+		TripID:    pgtype.UUID{Valid: true, Bytes: tripID},
+		Recipient: NormalizeEmail(string(params.OwnerEmail)),
+		Payload:   []byte("{}"),
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to enqueue confirmation Email for CreateTrip: %w", err)
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return uuid.UUID{}, fmt.Errorf("pgstore: failed to commit tx for CreateTrip: %w", err)
@@ -50,3 +96,435 @@ func (q *Queries) CreateTrip(ctx context.Context, pool *pgxpool.Pool, params spe
 
 	return tripID, nil
 }
+
+// TripUpdateEmails returns the e-mails to queue along with an update of a
+// trip, given the updated trip and its participants.
+type TripUpdateEmails func(trip Trip, participants []GetParticipantsRow) ([]EnqueueEmailParams, error)
+
+// RescheduleTrip updates the trip, deletes the given activities, which no
+// longer fit its dates, and queues the e-mails about the update in one go. It
+// returns the updated trip, or pgx.ErrNoRows, wrapped, when params.Version is
+// no longer current.
+func (q *Queries) RescheduleTrip(ctx context.Context, pool *pgxpool.Pool, params UpdateTripParams, activityIDs []uuid.UUID, emails TripUpdateEmails) (Trip, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to begin tx for RescheduleTrip: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	trip, err := qtx.UpdateTrip(ctx, params)
+	if err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to update Trip for RescheduleTrip: %w", err)
+	}
+
+	if len(activityIDs) > 0 {
+		_, err = qtx.DeleteActivities(ctx, DeleteActivitiesParams{TripID: params.ID, Ids: activityIDs})
+		if err != nil {
+			return Trip{}, fmt.Errorf("pgstore: failed to delete Activities for RescheduleTrip: %w", err)
+		}
+	}
+
+	participants, err := qtx.GetParticipants(ctx, params.ID)
+	if err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to get Participants for RescheduleTrip: %w", err)
+	}
+
+	queue, err := emails(trip, participants)
+	if err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to build Emails for RescheduleTrip: %w", err)
+	}
+
+	for _, e := range queue {
+		err = qtx.EnqueueEmail(ctx, e)
+		if err != nil {
+			return Trip{}, fmt.Errorf("pgstore: failed to enqueue %s Email for RescheduleTrip: %w", e.Kind, err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return Trip{}, fmt.Errorf("pgstore: failed to commit tx for RescheduleTrip: %w", err)
+	}
+
+	return trip, nil
+}
+
+// CreateLoginToken inserts a login token and queues the e-mail with its link,
+// which email builds from the token ID.
+func (q *Queries) CreateLoginToken(ctx context.Context, pool *pgxpool.Pool, params InsertLoginTokenParams, email func(tokenID uuid.UUID) (EnqueueEmailParams, error)) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin tx for CreateLoginToken: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	tokenID, err := qtx.InsertLoginToken(ctx, params)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to insert login Token for CreateLoginToken: %w", err)
+	}
+
+	e, err := email(tokenID)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to build login Email for CreateLoginToken: %w", err)
+	}
+
+	err = qtx.EnqueueEmail(ctx, e)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to enqueue login Email for CreateLoginToken: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to commit tx for CreateLoginToken: %w", err)
+	}
+
+	return nil
+}
+
+// RequestEmailChange inserts the token that changes the e-mail of a user and
+// queues the e-mail with its link to the new address, which email builds from
+// the token ID.
+func (q *Queries) RequestEmailChange(ctx context.Context, pool *pgxpool.Pool, params InsertEmailChangeTokenParams, email func(tokenID uuid.UUID) (EnqueueEmailParams, error)) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin tx for RequestEmailChange: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	tokenID, err := qtx.InsertEmailChangeToken(ctx, params)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to insert email change Token for RequestEmailChange: %w", err)
+	}
+
+	e, err := email(tokenID)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to build email change Email for RequestEmailChange: %w", err)
+	}
+
+	err = qtx.EnqueueEmail(ctx, e)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to enqueue email change Email for RequestEmailChange: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to commit tx for RequestEmailChange: %w", err)
+	}
+
+	return nil
+}
+
+// ErrEmailTaken is returned by ChangeUserEmail when another user has the new
+// address.
+var ErrEmailTaken = errors.New("pgstore: email already in use")
+
+// ChangeUserEmail consumes the email change token of the user and changes
+// their e-mail to the address it was issued for, which it returns. It returns
+// ErrTokenUsed when the token was already used, and ErrEmailTaken when the
+// address belongs to someone else by now, changing nothing either way.
+func (q *Queries) ChangeUserEmail(ctx context.Context, pool *pgxpool.Pool, params ConsumeEmailChangeTokenParams) (string, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("pgstore: failed to begin tx for ChangeUserEmail: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	email, err := qtx.ConsumeEmailChangeToken(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrTokenUsed
+		}
+
+		return "", fmt.Errorf("pgstore: failed to consume email change Token for ChangeUserEmail: %w", err)
+	}
+
+	updated, err := qtx.UpdateUserEmail(ctx, UpdateUserEmailParams{Email: email, ID: params.UserID})
+	if err != nil {
+		return "", fmt.Errorf("pgstore: failed to update User email for ChangeUserEmail: %w", err)
+	}
+
+	if updated == 0 {
+		return "", ErrEmailTaken
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return "", fmt.Errorf("pgstore: failed to commit tx for ChangeUserEmail: %w", err)
+	}
+
+	return email, nil
+}
+
+// ErrTokenUsed is returned by the transactions that consume a single-use
+// token when it was already used, in which case they change nothing.
+var ErrTokenUsed = errors.New("pgstore: token already used")
+
+// ConfirmTrip moves the trip to params.ToStatus, consumes the confirmation
+// token and queues an invite to each pending participant not invited yet. It
+// returns 0 when the trip is no longer in params.FromStatus, in which case the
+// token is left unused and nothing is queued.
+func (q *Queries) ConfirmTrip(ctx context.Context, pool *pgxpool.Pool, params UpdateTripStatusParams, tokenID uuid.UUID) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to begin tx for ConfirmTrip: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	updated, err := qtx.UpdateTripStatus(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to update Trip status for ConfirmTrip: %w", err)
+	}
+
+	if updated == 0 {
+		return 0, nil
+	}
+
+	consumed, err := qtx.ConsumeConfirmationToken(ctx, tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to consume confirmation Token for ConfirmTrip: %w", err)
+	}
+
+	if consumed == 0 {
+		return 0, ErrTokenUsed
+	}
+
+	// Only participants who were never invited and haven't answered yet, so
+	// confirming a trip again after it went back to draft invites no one
+	// twice.
+	invited, err := qtx.MarkPendingParticipantsInvited(ctx, MarkPendingParticipantsInvitedParams{
+		InvitedAt: pgtype.Timestamptz{Valid: true, Time: time.Now()},
+		TripID:    params.ID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to mark Participants invited for ConfirmTrip: %w", err)
+	}
+
+	for _, p := range invited {
+		err = qtx.enqueueParticipantEmail(ctx, EmailInvite, params.ID, p.ID, p.Email)
+		if err != nil {
+			return 0, fmt.Errorf("pgstore: failed to enqueue invite Email for ConfirmTrip: %w", err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to commit tx for ConfirmTrip: %w", err)
+	}
+
+	return updated, nil
+}
+
+// AnswerInvite records the answer of the participant to their invite and
+// consumes the confirmation token of the invite. It returns 0 when the
+// participant already answered, in which case the token is left unused, and
+// ErrTokenUsed when the token was already used, changing nothing either way.
+func (q *Queries) AnswerInvite(ctx context.Context, pool *pgxpool.Pool, params UpdateParticipantRsvpParams, tokenID uuid.UUID) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to begin tx for AnswerInvite: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	updated, err := qtx.UpdateParticipantRsvp(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to update Participant rsvp for AnswerInvite: %w", err)
+	}
+
+	if updated == 0 {
+		return 0, nil
+	}
+
+	consumed, err := qtx.ConsumeConfirmationToken(ctx, tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to consume confirmation Token for AnswerInvite: %w", err)
+	}
+
+	if consumed == 0 {
+		return 0, ErrTokenUsed
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to commit tx for AnswerInvite: %w", err)
+	}
+
+	return updated, nil
+}
+
+// CancelTrip moves the trip to params.ToStatus and queues a cancellation
+// notice to each of its participants. It returns 0 when the trip is no longer
+// in params.FromStatus, in which case nothing is queued.
+func (q *Queries) CancelTrip(ctx context.Context, pool *pgxpool.Pool, params UpdateTripStatusParams) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to begin tx for CancelTrip: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	updated, err := qtx.UpdateTripStatus(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to update Trip status for CancelTrip: %w", err)
+	}
+
+	if updated == 0 {
+		return 0, nil
+	}
+
+	participants, err := qtx.GetParticipants(ctx, params.ID)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to get Participants for CancelTrip: %w", err)
+	}
+
+	for _, p := range participants {
+		err = qtx.enqueueParticipantEmail(ctx, EmailTripCancelled, params.ID, p.ID, p.Email)
+		if err != nil {
+			return 0, fmt.Errorf("pgstore: failed to enqueue cancellation Email for CancelTrip: %w", err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to commit tx for CancelTrip: %w", err)
+	}
+
+	return updated, nil
+}
+
+// InviteParticipant adds the user to the trip and queues their invite. It
+// returns pgx.ErrNoRows, wrapped, when the user is already a participant.
+func (q *Queries) InviteParticipant(ctx context.Context, pool *pgxpool.Pool, params InviteParticipantToTripParams, email string) (uuid.UUID, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to begin tx for InviteParticipant: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	participantID, err := qtx.InviteParticipantToTrip(ctx, params)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to insert Participant for InviteParticipant: %w", err)
+	}
+
+	now := pgtype.Timestamptz{Valid: true, Time: time.Now()}
+	_, err = qtx.MarkParticipantInvited(ctx, MarkParticipantInvitedParams{
+		InvitedAt:     now,
+		ID:            participantID,
+		TripID:        params.TripID,
+		InvitedBefore: now,
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to mark Participant invited for InviteParticipant: %w", err)
+	}
+
+	err = qtx.enqueueParticipantEmail(ctx, EmailInvite, params.TripID, participantID, email)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to enqueue invite Email for InviteParticipant: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("pgstore: failed to commit tx for InviteParticipant: %w", err)
+	}
+
+	return participantID, nil
+}
+
+// ResendInvite marks the participant invited again and queues a new invite.
+// It returns 0 when the participant was invited after params.InvitedBefore,
+// in which case nothing is queued.
+func (q *Queries) ResendInvite(ctx context.Context, pool *pgxpool.Pool, params MarkParticipantInvitedParams, email string) (int64, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to begin tx for ResendInvite: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	marked, err := qtx.MarkParticipantInvited(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to mark Participant invited for ResendInvite: %w", err)
+	}
+
+	if marked == 0 {
+		return 0, nil
+	}
+
+	err = qtx.enqueueParticipantEmail(ctx, EmailInvite, params.TripID, params.ID, email)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to enqueue invite Email for ResendInvite: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("pgstore: failed to commit tx for ResendInvite: %w", err)
+	}
+
+	return marked, nil
+}
+
+// ErrLinkOrderMismatch is returned by ReorderTripLinks when the given IDs are
+// not exactly the links of the trip.
+var ErrLinkOrderMismatch = errors.New("pgstore: link order must list every link of the trip once")
+
+func (q *Queries) ReorderTripLinks(ctx context.Context, pool *pgxpool.Pool, tripID uuid.UUID, linkIDs []uuid.UUID) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to begin tx for ReorderTripLinks: %w", err)
+	}
+	// Guarantees that connections is closed
+	defer tx.Rollback(ctx)
+
+	qtx := q.WithTx(tx)
+	links, err := qtx.GetTripLinks(ctx, tripID)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to get Links for ReorderTripLinks: %w", err)
+	}
+
+	if len(links) != len(linkIDs) {
+		return ErrLinkOrderMismatch
+	}
+
+	seen := make(map[uuid.UUID]bool, len(linkIDs))
+	for i, id := range linkIDs {
+		if seen[id] {
+			return ErrLinkOrderMismatch
+		}
+		seen[id] = true
+
+		updated, err := qtx.SetLinkPosition(ctx, SetLinkPositionParams{
+			Position: int32(i),
+			ID:       id,
+			TripID:   tripID,
+		})
+		if err != nil {
+			return fmt.Errorf("pgstore: failed to set Link position for ReorderTripLinks: %w", err)
+		}
+
+		if updated == 0 {
+			return ErrLinkOrderMismatch
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("pgstore: failed to commit tx for ReorderTripLinks: %w", err)
+	}
+
+	return nil
+}
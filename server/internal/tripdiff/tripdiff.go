@@ -0,0 +1,50 @@
+// Package tripdiff describes what an update changed about a trip, in the
+// terms participants are told about it.
+package tripdiff
+
+import (
+	"server/internal/pgstore"
+	"time"
+)
+
+// Keys of the trip details a Change is about. They are stored in queued
+// e-mails, so they stay the same whatever language the e-mail is written in.
+const (
+	FieldDestination = "destination"
+	FieldStartsAt    = "starts_at"
+	FieldEndsAt      = "ends_at"
+)
+
+// Change is a trip detail that an update changed, with its value before and
+// after. A destination change sets Old and New, a date change sets OldDate
+// and NewDate, in the trip's timezone before and after the update.
+type Change struct {
+	Field   string    `json:"field"`
+	Old     string    `json:"old,omitempty"`
+	New     string    `json:"new,omitempty"`
+	OldDate time.Time `json:"old_date"`
+	NewDate time.Time `json:"new_date"`
+}
+
+// Diff returns the details participants see that differ between old and new.
+// Dates are compared as the calendar days of each trip's own timezone, so
+// moving a trip within a day, or to a timezone where it keeps its days, is
+// no change to them.
+func Diff(old, new pgstore.Trip) []Change {
+	var changes []Change
+	if old.Destination != new.Destination {
+		changes = append(changes, Change{Field: FieldDestination, Old: old.Destination, New: new.Destination})
+	}
+
+	oldLoc, newLoc := pgstore.Location(old.Timezone), pgstore.Location(new.Timezone)
+	addDate := func(field string, before, after time.Time) {
+		before, after = before.In(oldLoc), after.In(newLoc)
+		if before.Format(time.DateOnly) != after.Format(time.DateOnly) {
+			changes = append(changes, Change{Field: field, OldDate: before, NewDate: after})
+		}
+	}
+	addDate(FieldStartsAt, old.StartsAt.Time, new.StartsAt.Time)
+	addDate(FieldEndsAt, old.EndsAt.Time, new.EndsAt.Time)
+
+	return changes
+}
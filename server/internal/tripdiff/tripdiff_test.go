@@ -0,0 +1,101 @@
+package tripdiff
+
+import (
+	"encoding/json"
+	"server/internal/pgstore"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func at(year int, month time.Month, day, hour int) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Valid: true, Time: time.Date(year, month, day, hour, 0, 0, 0, time.UTC)}
+}
+
+func TestDiff(t *testing.T) {
+	// Midnight in São Paulo is 03:00 UTC.
+	base := pgstore.Trip{
+		Destination: "Florianópolis, Brazil",
+		StartsAt:    at(2024, time.July, 10, 3),
+		EndsAt:      at(2024, time.July, 17, 3),
+		Timezone:    "America/Sao_Paulo",
+	}
+
+	tests := []struct {
+		name   string
+		update func(*pgstore.Trip)
+		want   []string
+	}{
+		{"nothing", func(*pgstore.Trip) {}, nil},
+		{"destination", func(t *pgstore.Trip) { t.Destination = "Rio de Janeiro, Brazil" }, []string{FieldDestination}},
+		{"starts at", func(t *pgstore.Trip) { t.StartsAt = at(2024, time.July, 9, 3) }, []string{FieldStartsAt}},
+		{"ends at", func(t *pgstore.Trip) { t.EndsAt = at(2024, time.July, 18, 3) }, []string{FieldEndsAt}},
+		{"every field", func(t *pgstore.Trip) {
+			t.Destination = "Rio de Janeiro, Brazil"
+			t.StartsAt = at(2024, time.July, 11, 3)
+			t.EndsAt = at(2024, time.July, 20, 3)
+		}, []string{FieldDestination, FieldStartsAt, FieldEndsAt}},
+		{"start within the day", func(t *pgstore.Trip) { t.StartsAt = at(2024, time.July, 10, 15) }, nil},
+		// 03:00 UTC is midnight in São Paulo and 04:00 in Lisbon, same days.
+		{"timezone keeping the days", func(t *pgstore.Trip) { t.Timezone = "Europe/Lisbon" }, nil},
+		// But 02:00 in Los Angeles the day before.
+		{"timezone moving the days", func(t *pgstore.Trip) { t.Timezone = "America/Los_Angeles" }, []string{FieldStartsAt, FieldEndsAt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := base
+			tt.update(&updated)
+
+			changes := Diff(base, updated)
+			if len(changes) != len(tt.want) {
+				t.Fatalf("Diff returned %+v, want changes to %v", changes, tt.want)
+			}
+			for i, c := range changes {
+				if c.Field != tt.want[i] {
+					t.Errorf("change %d is to %s, want %s", i, c.Field, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	old := pgstore.Trip{
+		Destination: "Lisbon",
+		StartsAt:    at(2024, time.July, 10, 3),
+		EndsAt:      at(2024, time.July, 17, 3),
+		Timezone:    "America/Sao_Paulo",
+	}
+	new := old
+	new.Destination = "Porto"
+	new.StartsAt = at(2024, time.July, 12, 3)
+
+	changes := Diff(old, new)
+	if len(changes) != 2 {
+		t.Fatalf("Diff returned %+v, want 2 changes", changes)
+	}
+
+	if changes[0].Old != "Lisbon" || changes[0].New != "Porto" {
+		t.Errorf("destination changed from %q to %q, want Lisbon to Porto", changes[0].Old, changes[0].New)
+	}
+
+	// The changes are queued with the e-mail, the days must survive that.
+	data, err := json.Marshal(changes[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var start Change
+	err = json.Unmarshal(data, &start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := start.OldDate.Format(time.DateOnly); got != "2024-07-10" {
+		t.Errorf("old start is %s, want 2024-07-10", got)
+	}
+	if got := start.NewDate.Format(time.DateOnly); got != "2024-07-12" {
+		t.Errorf("new start is %s, want 2024-07-12", got)
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"os/signal"
 	"server/internal/api"
 	"server/internal/api/spec"
+	"server/internal/auth"
 	"server/internal/email"
+	"server/internal/jobs"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -17,7 +20,6 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
-	"github.com/wneessen/go-mail"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -69,16 +71,37 @@ func run(ctx context.Context) error {
 		return err
 	}
 
-	mailClient, err := mail.NewClient("mailpit", mail.WithTLSPortPolicy(mail.NoTLS), mail.WithPort(1025))
+	mailConf, err := email.ConfigFromEnv()
 	if err != nil {
-		return fmt.Errorf("failed to create mail client: %w", err)
+		return err
+	}
+
+	transport, err := email.NewTransport(mailConf)
+	if err != nil {
+		return fmt.Errorf("failed to create mail transport: %w", err)
+	}
+
+	secret := os.Getenv("AUTH_SECRET")
+	if secret == "" {
+		return errors.New("AUTH_SECRET must be set")
+	}
+
+	concurrency := 4
+	if v := os.Getenv("JOBS_CONCURRENCY"); v != "" {
+		concurrency, err = strconv.Atoi(v)
+		if err != nil || concurrency < 1 {
+			return errors.New("JOBS_CONCURRENCY must be a positive number")
+		}
 	}
-	defer mailClient.Close()
+	workers := jobs.NewPool(logger, concurrency, 100)
+
+	si := api.NewAPI(pool, logger, email.NewEmail(pool, transport, mailConf.From), auth.NewSigner(secret), os.Getenv("APP_URL"))
+	routes := chi.NewRouter()
+	spec.Handler(&si, spec.WithRouter(routes), spec.WithErrorHandler(si.ParamError))
 
-	si := api.NewAPI(pool, logger, email.NewEmail(pool, mailClient))
 	r := chi.NewRouter()
-	r.Use(middleware.RequestID, middleware.Recoverer, middleware.Logger)
-	r.Mount("/", spec.Handler(&si))
+	r.Use(middleware.RequestID, middleware.Recoverer, middleware.Logger, si.Authorize(routes))
+	r.Mount("/", routes)
 
 	srv := &http.Server{
 		Addr:         ":8080",
@@ -88,6 +111,15 @@ func run(ctx context.Context) error {
 		WriteTimeout: 5 * time.Second,
 	}
 
+	dispatchCtx, stopDispatch := context.WithCancel(ctx)
+	dispatched := make(chan struct{})
+	go func() {
+		defer close(dispatched)
+		si.DispatchEmails(dispatchCtx, workers)
+	}()
+
+	// Stop taking requests and new jobs, then give the jobs already queued
+	// or running what is left of the timeout to finish.
 	defer func() {
 		const timeout = 30 * time.Second
 		ctx, cancel := context.WithTimeout(context.Background(), timeout)
@@ -97,6 +129,16 @@ func run(ctx context.Context) error {
 		if err != nil {
 			logger.Error("Failed to shutdown server", zap.Error(err))
 		}
+
+		stopDispatch()
+		<-dispatched
+
+		err = workers.Shutdown(ctx)
+		if err != nil {
+			logger.Error("Failed to drain background jobs", zap.Error(err), zap.Any("stats", workers.Stats()))
+			return
+		}
+		logger.Info("Drained background jobs", zap.Any("stats", workers.Stats()))
 	}()
 
 	errChan := make(chan error, 1)